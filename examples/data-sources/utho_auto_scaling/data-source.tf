@@ -0,0 +1,4 @@
+data "utho_auto_scaling" "example" {
+  name   = "autoscaling-ywqo2pmc"
+  dcslug = "innoida"
+}
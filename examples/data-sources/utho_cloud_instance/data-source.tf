@@ -0,0 +1,4 @@
+data "utho_cloud_instance" "example" {
+  name   = "myweb1.server.com"
+  dcslug = "innoida"
+}
@@ -0,0 +1,21 @@
+data "utho_cloud_instances" "web" {
+  filter {
+    name     = "name"
+    values   = ["^web-"]
+    match_by = "regex"
+  }
+
+  filter {
+    name   = "dcslug"
+    values = ["innoida"]
+  }
+
+  sort {
+    key       = "created_at"
+    direction = "desc"
+  }
+}
+
+output "web_ips" {
+  value = data.utho_cloud_instances.web.cloud_instances[*].ip
+}
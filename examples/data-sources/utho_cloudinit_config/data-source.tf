@@ -0,0 +1,26 @@
+data "utho_cloudinit_config" "example" {
+  part {
+    content_type = "text/cloud-config"
+    content      = <<-EOT
+      packages:
+        - nginx
+    EOT
+  }
+
+  part {
+    content_type = "text/x-shellscript"
+    filename     = "setup.sh"
+    content      = <<-EOT
+      #!/bin/sh
+      systemctl enable --now nginx
+    EOT
+  }
+}
+
+resource "utho_cloud_instance" "example" {
+  name      = "example-name"
+  dcslug    = "inmumbaizone2"
+  image     = "ubuntu-22.04-x86_64"
+  planid    = "10045"
+  user_data = data.utho_cloudinit_config.example.rendered
+}
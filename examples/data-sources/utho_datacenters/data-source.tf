@@ -0,0 +1,14 @@
+data "utho_datacenters" "kubernetes" {
+  filter {
+    name   = "kubernetes"
+    values = ["true"]
+  }
+
+  sort {
+    key = "slug"
+  }
+}
+
+output "kubernetes_zones" {
+  value = data.utho_datacenters.kubernetes.datacenters[*].slug
+}
@@ -0,0 +1,3 @@
+data "utho_domain" "example" {
+  domain = "example.com"
+}
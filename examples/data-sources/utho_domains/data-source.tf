@@ -0,0 +1,5 @@
+data "utho_domains" "example" {
+  sort {
+    key = "domain"
+  }
+}
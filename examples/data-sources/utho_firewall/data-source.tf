@@ -0,0 +1,3 @@
+data "utho_firewall" "example" {
+  name = "example"
+}
@@ -0,0 +1,11 @@
+data "utho_firewalls" "example" {
+  filter {
+    name     = "name"
+    values   = ["prod"]
+    match_by = "substring"
+  }
+
+  sort {
+    key = "name"
+  }
+}
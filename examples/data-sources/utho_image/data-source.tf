@@ -0,0 +1,9 @@
+data "utho_image" "ubuntu" {
+  distribution = "ubuntu"
+  architecture = "x86_64"
+  most_recent  = true
+}
+
+output "ubuntu_image" {
+  value = data.utho_image.ubuntu.image
+}
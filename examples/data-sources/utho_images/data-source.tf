@@ -1,2 +1,8 @@
 data "utho_images" "example" {
 }
+
+data "utho_images" "ubuntu" {
+  distribution  = "ubuntu"
+  version_regex = "^22\\."
+  architecture  = "x86_64"
+}
@@ -0,0 +1,3 @@
+data "utho_instance_template" "web" {
+  name = "web"
+}
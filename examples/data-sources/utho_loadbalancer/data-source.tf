@@ -0,0 +1,3 @@
+data "utho_loadbalancer" "example" {
+  id = "1234567"
+}
@@ -0,0 +1,6 @@
+data "utho_loadbalancers" "example" {
+  filter {
+    name   = "type"
+    values = ["application"]
+  }
+}
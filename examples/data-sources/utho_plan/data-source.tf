@@ -0,0 +1,11 @@
+data "utho_plan" "small" {
+  dcslug   = "innoida"
+  min_cpu  = 2
+  min_ram  = 4096
+  min_disk = 80
+  plantype = "basic"
+}
+
+output "planid" {
+  value = data.utho_plan.small.id
+}
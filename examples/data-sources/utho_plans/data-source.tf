@@ -0,0 +1,6 @@
+data "utho_plans" "example" {
+  dcslug    = "innoida"
+  min_cpu   = 2
+  min_ram   = 4096
+  max_price = 2000
+}
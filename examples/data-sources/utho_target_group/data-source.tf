@@ -0,0 +1,3 @@
+data "utho_target_group" "example" {
+  name = "my_group"
+}
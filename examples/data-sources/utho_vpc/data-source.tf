@@ -0,0 +1,4 @@
+data "utho_vpc" "example" {
+  name   = "vpc1"
+  dcslug = "innoida"
+}
@@ -0,0 +1,6 @@
+data "utho_vpcs" "example" {
+  filter {
+    name   = "dcslug"
+    values = ["innoida"]
+  }
+}
@@ -1,3 +1,9 @@
 provider "utho" {
   token = "token_value"
-}
\ No newline at end of file
+
+  default_tags {
+    tags = {
+      team = "platform"
+    }
+  }
+}
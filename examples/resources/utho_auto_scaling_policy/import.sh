@@ -0,0 +1,2 @@
+# Autoscaling policies can be imported using the autoscaling group id and the policy id
+terraform import utho_auto_scaling_policy.cpu_high <group_id>/<policy_id>
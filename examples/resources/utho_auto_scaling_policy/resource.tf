@@ -0,0 +1,10 @@
+resource "utho_auto_scaling_policy" "cpu_high" {
+  group_id = utho_auto_scaling.example.id
+  name     = "cpu-high"
+  type     = "cpu"
+  compare  = "above"
+  value    = 80
+  adjust   = "1"
+  period   = 5
+  cooldown = 300
+}
@@ -0,0 +1,2 @@
+# Autoscaling schedules can be imported using the autoscaling group id and the schedule id
+terraform import utho_auto_scaling_schedule.business_hours <group_id>/<schedule_id>
@@ -0,0 +1,8 @@
+resource "utho_auto_scaling_schedule" "business_hours" {
+  group_id    = utho_auto_scaling.example.id
+  name        = "business-hours"
+  desiredsize = 3
+  recurrence  = "daily"
+  start_date  = "2024-07-01 09:00:00"
+  timezone    = "Asia/Kolkata"
+}
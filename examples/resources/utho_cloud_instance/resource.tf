@@ -10,5 +10,16 @@ resource "utho_cloud_instance" "example" {
   vpc_id          = "4de5f07a-f51c-4323-b39a-ef66130e1bd9"
   cpumodel        = "amd"
   enable_publicip = "true"
-  root_password   = "qwe123"
+
+  tags = {
+    app = "web"
+  }
+
+  # changed in place
+  ipv6_enabled          = true
+  additional_public_ips = 1
+}
+
+output "example_ipv6_address" {
+  value = utho_cloud_instance.example.ipv6_address
 }
@@ -0,0 +1,2 @@
+# Custom isos can be imported using the iso id
+terraform import utho_custom_iso.rescue <iso_id>
@@ -0,0 +1,14 @@
+resource "utho_custom_iso" "rescue" {
+  name   = "systemrescue"
+  dcslug = "inmumbaizone2"
+  url    = "https://example.com/isos/systemrescue-11.00-amd64.iso"
+}
+
+# boot the instance from the iso, remove mounted_iso_id to unmount it
+resource "utho_cloud_instance" "web" {
+  name           = "web"
+  dcslug         = "inmumbaizone2"
+  image          = "ubuntu-22.04-x86_64"
+  planid         = "10045"
+  mounted_iso_id = utho_custom_iso.rescue.id
+}
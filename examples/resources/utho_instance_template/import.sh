@@ -0,0 +1,3 @@
+# Instance templates can be imported using the template id.
+# planid, os_disk_size, firewall_id and vpc_id are not stored by utho, the next apply sets them from the configuration
+terraform import utho_instance_template.web <template_id>
@@ -0,0 +1,37 @@
+resource "utho_instance_template" "web" {
+  name         = "web"
+  image        = "ubuntu-22.04-x86_64"
+  planid       = "10045"
+  os_disk_size = 80
+  ssh_keys     = [file("~/.ssh/id_ed25519.pub")]
+  user_data    = <<-EOT
+    #!/bin/bash
+    apt-get update -y && apt-get install -y nginx
+  EOT
+  firewall_id  = utho_firewall.web.id
+  vpc_id       = utho_vpc.example.id
+}
+
+# changing the image, ssh_keys or user_data creates a new template, the group instances are then
+# replaced in batches, without instance_refresh the whole group is replaced.
+# Changing the plan, disk size, firewall or vpc of the template replaces the group
+resource "utho_auto_scaling" "web" {
+  name                = "web"
+  dcslug              = "inmumbaizone2"
+  minsize             = 1
+  maxsize             = 3
+  desiredsize         = 1
+  planname            = "basic"
+  instance_templateid = "none"
+  public_ip_enabled   = true
+  stackid             = utho_instance_template.web.id
+  stackimage          = utho_instance_template.web.image
+  planid              = utho_instance_template.web.planid
+  os_disk_size        = utho_instance_template.web.os_disk_size
+  security_group_id   = utho_instance_template.web.firewall_id
+  vpc_id              = utho_instance_template.web.vpc_id
+
+  instance_refresh = {
+    batch_size = 1
+  }
+}
@@ -0,0 +1,2 @@
+# Reverse dns can be imported using the ip address
+terraform import utho_reverse_dns.mail 103.146.242.55
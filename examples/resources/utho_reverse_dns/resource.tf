@@ -0,0 +1,4 @@
+resource "utho_reverse_dns" "mail" {
+  ip_address = utho_cloud_instance.mail.ip
+  hostname   = "mail.example.com"
+}
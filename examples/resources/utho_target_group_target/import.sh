@@ -0,0 +1,3 @@
+# Target group targets can be imported using the target group id and the target id.
+# Targets registered by a cloud instance are imported with its cloud_instance_id, other targets with their ip
+terraform import utho_target_group_target.web <targetgroup_id>/<target_id>
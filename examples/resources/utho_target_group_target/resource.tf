@@ -0,0 +1,13 @@
+resource "utho_target_group_target" "web" {
+  targetgroup_id    = utho_target_group.example.id
+  cloud_instance_id = utho_cloud_instance.web.id
+  backend_port      = 80
+  backend_protocol  = "HTTP"
+}
+
+resource "utho_target_group_target" "external" {
+  targetgroup_id   = utho_target_group.example.id
+  ip               = "103.146.242.55"
+  backend_port     = 80
+  backend_protocol = "HTTP"
+}
@@ -5,3 +5,12 @@ resource "utho_vpc" "example" {
   network = "10.210.100.0"
   size    = "24"
 }
+
+# allocate the first free /24 in 10.220.0.0/16 that does not overlap a vpc in the zone
+resource "utho_vpc" "allocated" {
+  dcslug   = "innoida"
+  name     = "vpc2"
+  planid   = "1008"
+  supernet = "10.220.0.0/16"
+  size     = "24"
+}
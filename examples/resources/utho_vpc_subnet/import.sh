@@ -0,0 +1,2 @@
+# Vpc subnets can be imported using the vpc id and the subnet id
+terraform import utho_vpc_subnet.web <vpc_id>/<subnet_id>
@@ -0,0 +1,30 @@
+resource "utho_vpc" "example" {
+  dcslug  = "innoida"
+  name    = "vpc1"
+  planid  = "1008"
+  network = "10.210.100.0"
+  size    = "24"
+}
+
+resource "utho_vpc_subnet" "web" {
+  vpc_id        = utho_vpc.example.id
+  name          = "web"
+  cidr          = "10.210.100.0/26"
+  assign_public = true
+}
+
+resource "utho_vpc_subnet" "db" {
+  vpc_id = utho_vpc.example.id
+  name   = "db"
+  cidr   = "10.210.100.64/26"
+}
+
+resource "utho_cloud_instance" "db" {
+  name         = "db"
+  dcslug       = "innoida"
+  image        = "ubuntu-22.04-x86_64"
+  planid       = "10045"
+  billingcycle = "hourly"
+  vpc_id       = utho_vpc.example.id
+  subnet_id    = utho_vpc_subnet.db.id
+}
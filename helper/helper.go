@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
-	"encoding/base64"
+	"fmt"
+	"math/big"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -34,18 +37,68 @@ func NewUthoRequest(ctx context.Context, method, url string, body []byte, token
 	return resp, nil
 }
 
-// GeneratePassword generates a random password of the given length.
-func GeneratePassword(length int) (string, error) {
-	byteLen := (length*6 + 7) / 8
-	b := make([]byte, byteLen)
-	_, err := rand.Read(b)
+// Password character classes, a generated password has at least one character of each class it uses.
+const (
+	PasswordLowercase = "abcdefghijklmnopqrstuvwxyz"
+	PasswordUppercase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	PasswordDigits    = "0123456789"
+	PasswordSpecial   = "!#%*+-=?@^_"
+)
+
+// GeneratePassword generates a random password of the given length from letters and digits,
+// and special characters when special is set.
+func GeneratePassword(length int, special bool) (string, error) {
+	classes := []string{PasswordLowercase, PasswordUppercase, PasswordDigits}
+	if special {
+		classes = append(classes, PasswordSpecial)
+	}
+	if length < len(classes) {
+		return "", fmt.Errorf("password length must be at least %d", len(classes))
+	}
+
+	// one character of each class, then any character, in a random order
+	password := make([]byte, 0, length)
+	for _, class := range classes {
+		c, err := randomChar(class)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+	charset := strings.Join(classes, "")
+	for len(password) < length {
+		c, err := randomChar(charset)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+func randomChar(charset string) (byte, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
 	if err != nil {
-		return "", err
+		return 0, err
 	}
-	password := base64.RawURLEncoding.EncodeToString(b)
-	if len(password) > length {
-		password = password[:length]
+
+	return charset[i.Int64()], nil
+}
+
+// ParseInt64 parses a numeric string returned by the api, returning 0 when it is empty or not a number.
+func ParseInt64(s string) int64 {
+	i, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0
 	}
 
-	return password, nil
+	return i
 }
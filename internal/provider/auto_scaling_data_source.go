@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// NewAutoScalingDataSource is a helper function to simplify the provider implementation.
+// The autoscaling group is looked up by id or by name, optionally within a zone.
+func NewAutoScalingDataSource() datasource.DataSource {
+	return &resourceDataSource{
+		resource:    NewAutoScalingResource,
+		idAttribute: "id",
+		lookup:      lookupAutoScaling,
+		dcslug:      true,
+	}
+}
+
+func lookupAutoScaling(client utho.Client, name, dcslug string) ([]string, error) {
+	groups, err := client.AutoScaling().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, group := range groups {
+		if group.Name == name && (dcslug == "" || group.Dcslug == dcslug) {
+			ids = append(ids, group.ID)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// implement resource interfaces.
+var (
+	_ resource.Resource                = &AutoScalingPolicyResource{}
+	_ resource.ResourceWithConfigure   = &AutoScalingPolicyResource{}
+	_ resource.ResourceWithImportState = &AutoScalingPolicyResource{}
+)
+
+// NewAutoScalingPolicyResource is a helper function to simplify the provider implementation.
+func NewAutoScalingPolicyResource() resource.Resource {
+	return &AutoScalingPolicyResource{}
+}
+
+// AutoScalingPolicyResource is the resource implementation.
+type AutoScalingPolicyResource struct {
+	client utho.Client
+}
+
+type AutoScalingPolicyResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	GroupID      types.String `tfsdk:"group_id"`
+	Name         types.String `tfsdk:"name"`
+	Type         types.String `tfsdk:"type"`
+	Compare      types.String `tfsdk:"compare"`
+	Value        types.Int64  `tfsdk:"value"`
+	Adjust       types.String `tfsdk:"adjust"`
+	Period       types.Int64  `tfsdk:"period"`
+	Cooldown     types.Int64  `tfsdk:"cooldown"`
+	CooldownTill types.String `tfsdk:"cooldown_till"`
+	Status       types.String `tfsdk:"status"`
+}
+
+// Metadata returns the resource type name.
+func (s *AutoScalingPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auto_scaling_policy"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *AutoScalingPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(utho.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected AutoScalingPolicy Data Source Configure Type",
+			fmt.Sprintf("Expected utho.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// Schema defines the schema for the resource.
+func (s *AutoScalingPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":       schema.StringAttribute{Computed: true, Description: "id", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"group_id": schema.StringAttribute{Required: true, Description: "Id of the autoscaling group", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"name":     schema.StringAttribute{Required: true, Description: "Policy name, unique within the group"},
+			"type":     schema.StringAttribute{Required: true, Description: "Metric the policy watches eg: cpu, ram"},
+			"compare": schema.StringAttribute{Required: true, Description: "compare eg: above, below",
+				Validators: []validator.String{stringvalidator.OneOf("above", "below")},
+			},
+			"value": schema.Int64Attribute{Required: true, Description: "Metric threshold that triggers the policy",
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"adjust": schema.StringAttribute{Required: true, Description: "Number of instances to add or remove when the policy triggers"},
+			"period": schema.Int64Attribute{Required: true, Description: "period in minutes",
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"cooldown": schema.Int64Attribute{Required: true, Description: "cooldown in seconds",
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"cooldown_till": schema.StringAttribute{Computed: true, Description: "cooldown_till"},
+			"status":        schema.StringAttribute{Computed: true, Description: "status"},
+		},
+	}
+}
+
+// Import using group_id/policy_id as the attribute
+func (s *AutoScalingPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "/")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: group_id/policy_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+}
+
+// Create a new resource.
+func (s *AutoScalingPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "create autoscaling policy")
+	// Retrieve values from plan
+	var plan AutoScalingPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	policy := autoScalingPolicyRequest(plan.policyModel())
+	policy.Product = autoScalingPolicyProduct
+	policy.Productid = plan.GroupID.ValueString()
+
+	tflog.Debug(ctx, "send create autoscaling policy request")
+	_, err := s.client.AutoScaling().CreatePolicy(policy)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating autoscaling policy",
+			"Could not create autoscaling policy, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// the create response does not always contain the policy id, look it up by name
+	policies, err := s.client.AutoScaling().ListPolicies(plan.GroupID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho autoscaling policy",
+			"Could not read utho autoscaling policies of group "+plan.GroupID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	var created *utho.Policy
+	for i := range policies {
+		if policies[i].Name == plan.Name.ValueString() {
+			created = &policies[i]
+		}
+	}
+	if created == nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho autoscaling policy",
+			"Could not find the created autoscaling policy "+plan.Name.ValueString()+" in group "+plan.GroupID.ValueString(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = types.StringValue(created.ID)
+	plan.CooldownTill = types.StringValue(created.CooldownTill)
+	plan.Status = types.StringValue(created.Status)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish create autoscaling policy")
+}
+
+// Read resource information.
+func (s *AutoScalingPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "read autoscaling policy")
+
+	// Get current state
+	var state AutoScalingPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "send get autoscaling policy request")
+	// Get refreshed policy value from utho
+	policy, err := s.client.AutoScaling().ReadPolicy(state.GroupID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho autoscaling policy",
+			"Could not read utho autoscaling policy "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Overwrite items with refreshed state
+	model := autoScalingPolicyModel(*policy)
+	state.Name = model.Name
+	state.Type = model.Type
+	state.Compare = model.Compare
+	state.Value = model.Value
+	state.Adjust = model.Adjust
+	state.Period = model.Period
+	state.Cooldown = model.Cooldown
+	state.CooldownTill = model.CooldownTill
+	state.Status = model.Status
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish get autoscaling policy request")
+}
+
+func (s *AutoScalingPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "update autoscaling policy")
+	var plan AutoScalingPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy := autoScalingPolicyRequest(plan.policyModel())
+	tflog.Debug(ctx, "send update autoscaling policy request")
+	_, err := s.client.AutoScaling().UpdatePolicy(utho.UpdateAutoScalingPolicyParams{
+		AutoScalingPolicyId: plan.ID.ValueString(),
+		Name:                policy.Name,
+		Type:                policy.Type,
+		Compare:             policy.Compare,
+		Value:               policy.Value,
+		Adjust:              policy.Adjust,
+		Period:              policy.Period,
+		Cooldown:            policy.Cooldown,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating utho autoscaling policy",
+			"Could not update utho autoscaling policy "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	updated, err := s.client.AutoScaling().ReadPolicy(plan.GroupID.ValueString(), plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho autoscaling policy",
+			"Could not read utho autoscaling policy "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	plan.CooldownTill = types.StringValue(updated.CooldownTill)
+	plan.Status = types.StringValue(updated.Status)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish update autoscaling policy")
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (s *AutoScalingPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "delete autoscaling policy")
+	// Get current state
+	var state AutoScalingPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "send delete autoscaling policy request")
+	// delete autoscaling policy
+	_, err := s.client.AutoScaling().DeletePolicy(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleteing utho autoscaling policy",
+			"Could not delete utho autoscaling policy "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// policyModel converts the resource model to the inline policy model.
+func (m AutoScalingPolicyResourceModel) policyModel() PolicyModel {
+	return PolicyModel{
+		Name:     m.Name,
+		Type:     m.Type,
+		Compare:  m.Compare,
+		Value:    m.Value,
+		Adjust:   m.Adjust,
+		Period:   m.Period,
+		Cooldown: m.Cooldown,
+	}
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccAutoScalingPolicyResource(t *testing.T) {
+	resourceName := "utho_auto_scaling_policy.example"
+	config := func(value int) string {
+		return providerConfig + fmt.Sprintf(`
+resource "utho_auto_scaling" "example" {
+	name                = "example-name"
+	os_disk_size        = 800
+	dcslug              = "inmumbaizone2"
+	minsize             = 1
+	maxsize             = 3
+	desiredsize         = 1
+	planid              = "10045"
+	planname            = "basic"
+	instance_templateid = "none"
+	public_ip_enabled   = "true"
+	stackid            = "6669341"
+	stackimage         = "ubuntu-22.04-x86_64"
+	vpc_id            = "4de5f07a-f51c-4323-b39a-ef66130e1bd9"
+}
+
+resource "utho_auto_scaling_policy" "example" {
+	group_id = utho_auto_scaling.example.id
+	name     = "cpu-high"
+	type     = "cpu"
+	compare  = "above"
+	value    = %d
+	adjust   = "1"
+	period   = 5
+	cooldown = 300
+}
+`, value)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(80),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "cpu-high"),
+					resource.TestCheckResourceAttr(resourceName, "value", "80"),
+					resource.TestCheckResourceAttr(resourceName, "period", "5"),
+					resource.TestCheckResourceAttrPair(resourceName, "group_id", "utho_auto_scaling.example", "id"),
+					resource.TestCheckNoResourceAttr("utho_auto_scaling.example", "policies"),
+
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "status"),
+				),
+			},
+			{
+				Config: config(90),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "value", "90"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("not found: %s", resourceName)
+					}
+					return rs.Primary.Attributes["group_id"] + "/" + rs.Primary.ID, nil
+				},
+			},
+		},
+	})
+}
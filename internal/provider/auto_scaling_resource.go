@@ -3,25 +3,37 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/terraform-provider-utho/helper"
 	"github.com/uthoplatforms/utho-go/utho"
 )
 
 // implement resource interfaces.
 var (
-	_ resource.Resource                = &AutoScalingResource{}
-	_ resource.ResourceWithConfigure   = &AutoScalingResource{}
-	_ resource.ResourceWithImportState = &AutoScalingResource{}
+	_ resource.Resource                     = &AutoScalingResource{}
+	_ resource.ResourceWithConfigure        = &AutoScalingResource{}
+	_ resource.ResourceWithImportState      = &AutoScalingResource{}
+	_ resource.ResourceWithModifyPlan       = &AutoScalingResource{}
+	_ resource.ResourceWithConfigValidators = &AutoScalingResource{}
+	_ resource.ResourceWithUpgradeState     = &AutoScalingResource{}
 )
 
 // NewAutoScalingResource is a helper function to simplify the provider implementation.
@@ -36,44 +48,52 @@ type AutoScalingResource struct {
 
 // AutoScalingResource is the model implementation.
 type AutoScalingResourceModel struct {
-	ID                 types.String  `tfsdk:"id"`
-	Userid             types.String  `tfsdk:"userid"`
-	Name               types.String  `tfsdk:"name"`
-	Dcslug             types.String  `tfsdk:"dcslug"`
-	Minsize            types.String  `tfsdk:"minsize"`
-	Maxsize            types.String  `tfsdk:"maxsize"`
-	Desiredsize        types.String  `tfsdk:"desiredsize"`
-	Planid             types.String  `tfsdk:"planid"`
-	Planname           types.String  `tfsdk:"planname"`
-	InstanceTemplateid types.String  `tfsdk:"instance_templateid"`
-	Image              types.String  `tfsdk:"image"`
-	ImageName          types.String  `tfsdk:"image_name"`
-	Snapshotid         types.String  `tfsdk:"snapshotid"`
-	Status             types.String  `tfsdk:"status"`
-	CreatedAt          types.String  `tfsdk:"created_at"`
-	SuspendedAt        types.String  `tfsdk:"suspended_at"`
-	StoppedAt          types.String  `tfsdk:"stopped_at"`
-	StartedAt          types.String  `tfsdk:"started_at"`
-	DeletedAt          types.String  `tfsdk:"deleted_at"`
-	PublicIPEnabled    types.Bool    `tfsdk:"public_ip_enabled"`
-	CooldownTill       types.String  `tfsdk:"cooldown_till"`
-	Backupid           types.String  `tfsdk:"backupid"`
-	Stackid            types.String  `tfsdk:"stackid"`
-	Stackimage         types.String  `tfsdk:"stackimage"`
-	VpcID              types.String  `tfsdk:"vpc_id"`
-	LoadbalancersID    types.String  `tfsdk:"loadbalancers_id"`
-	SecurityGroupID    types.String  `tfsdk:"security_group_id"`
-	TargetGroupsID     types.String  `tfsdk:"target_groups_id"`
-	OsDiskSize         types.Int64   `tfsdk:"os_disk_size"`
-	Policies           []PolicyModel `tfsdk:"policies"`
-	Schedules          types.List    `tfsdk:"schedules"`
-	Vpc                types.List    `tfsdk:"vpc"`
-	Loadbalancers      types.List    `tfsdk:"load_balancers"`
-	TargetGroups       types.List    `tfsdk:"target_groups"`
-	SecurityGroups     types.List    `tfsdk:"security_groups"`
-	Instances          types.List    `tfsdk:"instances"`
-	Dclocation         types.Object  `tfsdk:"dclocation"`
-	Plan               types.Object  `tfsdk:"plan"`
+	ID                 types.String          `tfsdk:"id"`
+	Userid             types.String          `tfsdk:"userid"`
+	Name               types.String          `tfsdk:"name"`
+	Dcslug             types.String          `tfsdk:"dcslug"`
+	Minsize            types.Int64           `tfsdk:"minsize"`
+	Maxsize            types.Int64           `tfsdk:"maxsize"`
+	Desiredsize        types.Int64           `tfsdk:"desiredsize"`
+	IgnoreDesiredSize  types.Bool            `tfsdk:"ignore_desired_capacity_changes"`
+	CurrentDesiredsize types.Int64           `tfsdk:"current_desiredsize"`
+	State              types.String          `tfsdk:"state"`
+	InstanceRefresh    *InstanceRefreshModel `tfsdk:"instance_refresh"`
+	OutdatedInstances  types.List            `tfsdk:"outdated_instances"`
+	Planid             types.String          `tfsdk:"planid"`
+	Planname           types.String          `tfsdk:"planname"`
+	InstanceTemplateid types.String          `tfsdk:"instance_templateid"`
+	Image              types.String          `tfsdk:"image"`
+	ImageName          types.String          `tfsdk:"image_name"`
+	Snapshotid         types.String          `tfsdk:"snapshotid"`
+	Status             types.String          `tfsdk:"status"`
+	CreatedAt          types.String          `tfsdk:"created_at"`
+	SuspendedAt        types.String          `tfsdk:"suspended_at"`
+	StoppedAt          types.String          `tfsdk:"stopped_at"`
+	StartedAt          types.String          `tfsdk:"started_at"`
+	DeletedAt          types.String          `tfsdk:"deleted_at"`
+	PublicIPEnabled    types.Bool            `tfsdk:"public_ip_enabled"`
+	CooldownTill       types.String          `tfsdk:"cooldown_till"`
+	Backupid           types.String          `tfsdk:"backupid"`
+	Stackid            types.String          `tfsdk:"stackid"`
+	Stackimage         types.String          `tfsdk:"stackimage"`
+	VpcID              types.String          `tfsdk:"vpc_id"`
+	LoadbalancersID    types.String          `tfsdk:"loadbalancers_id"`
+	SecurityGroupID    types.String          `tfsdk:"security_group_id"`
+	TargetGroupsID     types.String          `tfsdk:"target_groups_id"`
+	OsDiskSize         types.Int64           `tfsdk:"os_disk_size"`
+	Policies           []PolicyModel         `tfsdk:"policies"`
+	Schedules          []ScheduleModel       `tfsdk:"schedules"`
+	Vpc                types.List            `tfsdk:"vpc"`
+	Loadbalancers      types.List            `tfsdk:"load_balancers"`
+	TargetGroups       types.List            `tfsdk:"target_groups"`
+	SecurityGroups     types.List            `tfsdk:"security_groups"`
+	Instances          types.List            `tfsdk:"instances"`
+	Dclocation         types.Object          `tfsdk:"dclocation"`
+	Plan               types.Object          `tfsdk:"plan"`
+}
+type InstanceRefreshModel struct {
+	BatchSize types.Int64 `tfsdk:"batch_size"`
 }
 type AutoScalingVpcModel struct {
 	Total     types.Int64  `tfsdk:"total"`
@@ -114,11 +134,11 @@ type PolicyModel struct {
 	Name               types.String `tfsdk:"name"`
 	Type               types.String `tfsdk:"type"`
 	Adjust             types.String `tfsdk:"adjust"`
-	Period             types.String `tfsdk:"period"`
-	Cooldown           types.String `tfsdk:"cooldown"`
+	Period             types.Int64  `tfsdk:"period"`
+	Cooldown           types.Int64  `tfsdk:"cooldown"`
 	CooldownTill       types.String `tfsdk:"cooldown_till"`
 	Compare            types.String `tfsdk:"compare"`
-	Value              types.String `tfsdk:"value"`
+	Value              types.Int64  `tfsdk:"value"`
 	AlertID            types.String `tfsdk:"alert_id"`
 	Status             types.String `tfsdk:"status"`
 	KubernetesID       types.String `tfsdk:"kubernetes_id"`
@@ -131,7 +151,7 @@ type ScheduleModel struct {
 	ID          types.String `tfsdk:"id"`
 	Groupid     types.String `tfsdk:"groupid"`
 	Name        types.String `tfsdk:"name"`
-	Desiredsize types.String `tfsdk:"desiredsize"`
+	Desiredsize types.Int64  `tfsdk:"desiredsize"`
 	Recurrence  types.String `tfsdk:"recurrence"`
 	StartDate   types.String `tfsdk:"start_date"`
 	Status      types.String `tfsdk:"status"`
@@ -178,12 +198,23 @@ func (d *AutoScalingResource) Configure(_ context.Context, req resource.Configur
 // Schema defines the schema for the resource.
 func (s *AutoScalingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id":          schema.StringAttribute{Computed: true},
-			"name":        schema.StringAttribute{Required: true, Description: "Provide AUTOSCALING name eg: autoscaling-ywqo2pmc"},
-			"minsize":     schema.StringAttribute{Required: true},
-			"maxsize":     schema.StringAttribute{Required: true},
-			"desiredsize": schema.StringAttribute{Required: true},
+			"id":   schema.StringAttribute{Computed: true},
+			"name": schema.StringAttribute{Required: true, Description: "Provide AUTOSCALING name eg: autoscaling-ywqo2pmc"},
+			"minsize": schema.Int64Attribute{Required: true, Description: "Minimum number of instances in the group",
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"maxsize": schema.Int64Attribute{Required: true, Description: "Maximum number of instances in the group",
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"desiredsize": schema.Int64Attribute{Required: true, Description: "Number of instances the group should run, between minsize and maxsize",
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"ignore_desired_capacity_changes": schema.BoolAttribute{Optional: true,
+				Description: "Ignore changes of the desired size made by scaling policies and schedules. desiredsize is only sent on create or when it is changed in the configuration, the live value is available in current_desiredsize",
+			},
+			"current_desiredsize": schema.Int64Attribute{Computed: true, Description: "Number of instances the group currently wants to run"},
 			"dcslug": schema.StringAttribute{Required: true, Description: "Provide dcslug eg: innoida",
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
@@ -202,11 +233,28 @@ func (s *AutoScalingResource) Schema(_ context.Context, _ resource.SchemaRequest
 			"public_ip_enabled": schema.BoolAttribute{Required: true,
 				PlanModifiers: []planmodifier.Bool{},
 			},
-			"stackid": schema.StringAttribute{Required: true,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			"stackid": schema.StringAttribute{Required: true, Description: "Stack or utho_instance_template id the instances are launched from",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIf(requiresReplaceWithoutInstanceRefresh,
+					"Replaces the group unless instance_refresh is configured", "Replaces the group unless instance_refresh is configured")},
 			},
-			"stackimage": schema.StringAttribute{Required: true,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			"stackimage": schema.StringAttribute{Required: true, Description: "Image of the stack the instances are launched from",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIf(requiresReplaceWithoutInstanceRefresh,
+					"Replaces the group unless instance_refresh is configured", "Replaces the group unless instance_refresh is configured")},
+			},
+			"state": schema.StringAttribute{Optional: true, Computed: true, Description: "Scaling state of the group eg: active, suspended. A suspended group keeps its instances but does not scale",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				Validators:    []validator.String{stringvalidator.OneOf(autoScalingStateActive, autoScalingStateSuspended)},
+			},
+			"instance_refresh": schema.SingleNestedAttribute{Optional: true,
+				Description: "Apply stackid and stackimage changes in place by replacing the group instances in batches, instead of replacing the group. The group must be active while its instances are replaced",
+				Attributes: map[string]schema.Attribute{
+					"batch_size": schema.Int64Attribute{Optional: true, Description: "Maximum number of instances replaced at a time, defaults to 1. Instances are only replaced while the group keeps minsize instances",
+						Validators: []validator.Int64{int64validator.AtLeast(1)},
+					},
+				},
+			},
+			"outdated_instances": schema.ListAttribute{Computed: true, ElementType: types.StringType,
+				Description: "Instances launched before the last stackid or stackimage change that instance_refresh has not replaced yet. A refresh that stopped part way replaces them on the next apply",
 			},
 			"vpc_id": schema.StringAttribute{Required: true,
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
@@ -222,30 +270,24 @@ func (s *AutoScalingResource) Schema(_ context.Context, _ resource.SchemaRequest
 			},
 			"policies": schema.ListNestedAttribute{
 				Optional:    true,
-				Description: "policies",
+				Description: "Scaling policies of the group. Leave unset when policies are managed with utho_auto_scaling_policy",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"id": schema.StringAttribute{Computed: true, Description: "id"},
-						"name": schema.StringAttribute{Required: true, Description: "name",
-							PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
-						},
-						"type": schema.StringAttribute{Required: true, Description: "type",
-							PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
-						},
-						"compare": schema.StringAttribute{Required: true, Description: "compare",
-							PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
-						},
-						"value": schema.StringAttribute{Required: true, Description: "value",
-							PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+						"id":   schema.StringAttribute{Computed: true, Description: "id"},
+						"name": schema.StringAttribute{Required: true, Description: "Policy name, unique within the group"},
+						"type": schema.StringAttribute{Required: true, Description: "Metric the policy watches eg: cpu, ram"},
+						"compare": schema.StringAttribute{Required: true, Description: "compare eg: above, below",
+							Validators: []validator.String{stringvalidator.OneOf("above", "below")},
 						},
-						"adjust": schema.StringAttribute{Required: true, Description: "adjust",
-							PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+						"value": schema.Int64Attribute{Required: true, Description: "value",
+							Validators: []validator.Int64{int64validator.AtLeast(0)},
 						},
-						"period": schema.StringAttribute{Required: true, Description: "period",
-							PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+						"adjust": schema.StringAttribute{Required: true, Description: "Number of instances to add or remove when the policy triggers"},
+						"period": schema.Int64Attribute{Required: true, Description: "period in minutes",
+							Validators: []validator.Int64{int64validator.AtLeast(1)},
 						},
-						"cooldown": schema.StringAttribute{Required: true, Description: "cooldown",
-							PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+						"cooldown": schema.Int64Attribute{Required: true, Description: "cooldown in seconds",
+							Validators: []validator.Int64{int64validator.AtLeast(0)},
 						},
 						"userid":              schema.StringAttribute{Computed: true, Description: "userid"},
 						"product":             schema.StringAttribute{Computed: true, Description: "product"},
@@ -336,18 +378,20 @@ func (s *AutoScalingResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 			},
 			"schedules": schema.ListNestedAttribute{
-				Computed:    true,
-				Description: "",
+				Optional:    true,
+				Description: "Scheduled changes of the desired size. Leave unset when schedules are managed with utho_auto_scaling_schedule",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"id":          schema.StringAttribute{Computed: true},
-						"groupid":     schema.StringAttribute{Computed: true},
-						"name":        schema.StringAttribute{Computed: true},
-						"desiredsize": schema.StringAttribute{Computed: true},
-						"recurrence":  schema.StringAttribute{Computed: true},
-						"start_date":  schema.StringAttribute{Computed: true},
-						"status":      schema.StringAttribute{Computed: true},
-						"timezone":    schema.StringAttribute{Computed: true},
+						"id":      schema.StringAttribute{Computed: true, Description: "id"},
+						"groupid": schema.StringAttribute{Computed: true, Description: "groupid"},
+						"name":    schema.StringAttribute{Required: true, Description: "Schedule name, unique within the group"},
+						"desiredsize": schema.Int64Attribute{Required: true, Description: "Number of instances the group should run once the schedule triggers",
+							Validators: []validator.Int64{int64validator.AtLeast(0)},
+						},
+						"recurrence": schema.StringAttribute{Required: true, Description: "How often the schedule triggers eg: once, daily, weekly, monthly"},
+						"start_date": schema.StringAttribute{Required: true, Description: "Date and time the schedule first triggers eg: 2024-07-01 10:00:00"},
+						"timezone":   schema.StringAttribute{Optional: true, Description: "Timezone of start_date eg: Asia/Kolkata"},
+						"status":     schema.StringAttribute{Computed: true, Description: "status"},
 					},
 				},
 			},
@@ -377,6 +421,48 @@ func (s *AutoScalingResource) Schema(_ context.Context, _ resource.SchemaRequest
 	}
 }
 
+// ConfigValidators checks that the desired size is within the group size limits.
+func (s *AutoScalingResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		autoScalingSizeValidator{},
+	}
+}
+
+// UpgradeState migrates the numeric attributes that were stored as strings before version 1.
+// Schedules were computed before version 1, they are cleared so the existing schedules stay unmanaged
+// instead of being deleted by the next apply without a schedules block.
+func (s *AutoScalingResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeRawState(req, resp, map[string]stateStringConverter{
+					"minsize":             stringToInt64,
+					"maxsize":             stringToInt64,
+					"desiredsize":         stringToInt64,
+					"policies.*.value":    stringToInt64,
+					"policies.*.cooldown": stringToInt64,
+					"policies.*.period": func(period string) (any, error) {
+						if period == "" {
+							return nil, nil
+						}
+						return parsePolicyPeriod(period), nil
+					},
+				})
+				clearRawState(resp, "schedules")
+			},
+		},
+	}
+}
+
+// ModifyPlan validates the planned values against the utho api.
+func (s *AutoScalingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validateDcslugPlan(ctx, s.client, req, resp)
+	validatePlanidPlan(ctx, s.client, req, resp)
+	validateImagePlan(ctx, s.client, req, resp, "stackimage")
+	validateInstanceRefreshPlan(ctx, req, resp)
+	planOutdatedInstances(ctx, req, resp)
+}
+
 // Import using autoscaling as the attribute
 func (s *AutoScalingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
@@ -396,42 +482,27 @@ func (s *AutoScalingResource) Create(ctx context.Context, req resource.CreateReq
 	// Generate API request body from plan
 	policies := []utho.CreatePoliciesParams{}
 	for _, v := range plan.Policies {
+		request := autoScalingPolicyRequest(v)
 		policy := utho.CreatePoliciesParams{
-			Name:     v.Name.ValueString(),
-			Type:     v.Type.ValueString(),
-			Compare:  v.Compare.ValueString(),
-			Value:    v.Value.ValueString(),
-			Adjust:   v.Adjust.ValueString(),
-			Period:   v.Period.ValueString(),
-			Cooldown: v.Cooldown.ValueString(),
+			Name:     request.Name,
+			Type:     request.Type,
+			Compare:  request.Compare,
+			Value:    request.Value,
+			Adjust:   request.Adjust,
+			Period:   request.Period,
+			Cooldown: request.Cooldown,
 		}
 		policies = append(policies, policy)
 	}
 
-	schedules := []utho.CreateSchedulesParams{}
-	if len(plan.Schedules.Elements()) > 0 {
-		_ = plan.Schedules.ElementsAs(ctx, &schedules, false)
-		for _, v := range schedules {
-			schedule := utho.CreateSchedulesParams{
-				Name:         v.Name,
-				Desiredsize:  v.Desiredsize,
-				StartDate:    time.Now(),
-				SelectedTime: v.SelectedTime,
-				SelectedDate: v.SelectedDate,
-			}
-			schedules = append(schedules, schedule)
-		}
-
-	}
-
 	autoscalingRequest := utho.CreateAutoScalingParams{
 		Name:               plan.Name.ValueString(),
 		Dcslug:             plan.Dcslug.ValueString(),
 		Planid:             plan.Planid.ValueString(),
 		OsDiskSize:         int(plan.OsDiskSize.ValueInt64()),
-		Minsize:            plan.Minsize.ValueString(),
-		Maxsize:            plan.Maxsize.ValueString(),
-		Desiredsize:        plan.Desiredsize.ValueString(),
+		Minsize:            strconv.FormatInt(plan.Minsize.ValueInt64(), 10),
+		Maxsize:            strconv.FormatInt(plan.Maxsize.ValueInt64(), 10),
+		Desiredsize:        strconv.FormatInt(plan.Desiredsize.ValueInt64(), 10),
 		Planname:           plan.Planname.ValueString(),
 		InstanceTemplateid: plan.InstanceTemplateid.ValueString(),
 		PublicIPEnabled:    plan.PublicIPEnabled.ValueBool(),
@@ -443,7 +514,8 @@ func (s *AutoScalingResource) Create(ctx context.Context, req resource.CreateReq
 		Policies:           policies,
 		SecurityGroups:     plan.SecurityGroupID.ValueString(),
 		TargetGroups:       plan.TargetGroupsID.ValueString(),
-		Schedules:          schedules,
+		// schedules are created once the group exists, see below
+		Schedules: []utho.CreateSchedulesParams{},
 	}
 	tflog.Debug(ctx, "send create autoscaling request")
 	autoscaling, err := s.client.AutoScaling().Create(autoscalingRequest)
@@ -455,6 +527,30 @@ func (s *AutoScalingResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	for _, v := range plan.Schedules {
+		tflog.Debug(ctx, "send create autoscaling schedule request")
+		_, err := createAutoScalingSchedule(s.client, strconv.Itoa(autoscaling.ID), autoScalingScheduleRequest(v))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating autoscaling schedule",
+				"Could not create autoscaling schedule "+v.Name.ValueString()+", unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if plan.State.ValueString() == autoScalingStateSuspended {
+		tflog.Debug(ctx, "send suspend autoscaling request")
+		_, err := suspendAutoScaling(s.client, strconv.Itoa(autoscaling.ID))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error suspending autoscaling",
+				"Could not suspend autoscaling "+strconv.Itoa(autoscaling.ID)+", unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	// get autoscaling data
 	getAutoScaling, err := s.client.AutoScaling().Read(strconv.Itoa(autoscaling.ID))
 	if err != nil {
@@ -467,33 +563,6 @@ func (s *AutoScalingResource) Create(ctx context.Context, req resource.CreateReq
 
 	osDiskSize, _ := strconv.Atoi(getAutoScaling.Plan.Disk)
 
-	policiesModel := []PolicyModel{}
-	for _, v := range getAutoScaling.Policies {
-		policy := PolicyModel{
-			ID:                 types.StringValue(v.ID),
-			Userid:             types.StringValue(v.Userid),
-			Product:            types.StringValue(v.Product),
-			Productid:          types.StringValue(v.Productid),
-			Groupid:            types.StringValue(v.Groupid),
-			Name:               types.StringValue(v.Name),
-			Type:               types.StringValue(v.Type),
-			Adjust:             types.StringValue(v.Adjust),
-			Period:             types.StringValue(v.Period),
-			Cooldown:           types.StringValue(v.Cooldown),
-			CooldownTill:       types.StringValue(v.CooldownTill),
-			Compare:            types.StringValue(v.Compare),
-			Value:              types.StringValue(v.Value),
-			AlertID:            types.StringValue(v.AlertID),
-			Status:             types.StringValue(v.Status),
-			KubernetesID:       types.StringValue(v.KubernetesID),
-			KubernetesNodepool: types.StringValue(v.KubernetesNodepool),
-			Cloudid:            types.StringValue(v.Cloudid),
-			Maxsize:            types.StringValue(v.Maxsize),
-			Minsize:            types.StringValue(v.Minsize),
-		}
-		policiesModel = append(policiesModel, policy)
-	}
-
 	if !plan.LoadbalancersID.IsNull() {
 		plan.LoadbalancersID = types.StringValue(plan.LoadbalancersID.ValueString())
 	}
@@ -517,9 +586,12 @@ func (s *AutoScalingResource) Create(ctx context.Context, req resource.CreateReq
 	plan.Dcslug = types.StringValue(plan.Dcslug.ValueString())
 	plan.Userid = types.StringValue(getAutoScaling.Userid)
 	plan.Name = types.StringValue(getAutoScaling.Name)
-	plan.Minsize = types.StringValue(getAutoScaling.Minsize)
-	plan.Maxsize = types.StringValue(getAutoScaling.Maxsize)
-	plan.Desiredsize = types.StringValue(getAutoScaling.Desiredsize)
+	plan.Minsize = types.Int64Value(helper.ParseInt64(getAutoScaling.Minsize))
+	plan.Maxsize = types.Int64Value(helper.ParseInt64(getAutoScaling.Maxsize))
+	plan.CurrentDesiredsize = types.Int64Value(helper.ParseInt64(getAutoScaling.Desiredsize))
+	if !plan.IgnoreDesiredSize.ValueBool() {
+		plan.Desiredsize = plan.CurrentDesiredsize
+	}
 	plan.Planid = types.StringValue(getAutoScaling.Planid)
 	plan.Planname = types.StringValue(getAutoScaling.Planname)
 	plan.InstanceTemplateid = types.StringValue(getAutoScaling.InstanceTemplateid)
@@ -535,12 +607,21 @@ func (s *AutoScalingResource) Create(ctx context.Context, req resource.CreateReq
 	plan.PublicIPEnabled = types.BoolValue(publicIPEnabled)
 	plan.CooldownTill = types.StringValue(getAutoScaling.CooldownTill)
 	plan.Backupid = types.StringValue(getAutoScaling.Backupid)
-	// TODO
+	// the api does not return the stack and its image, keep the planned values
 	plan.Stackid = types.StringValue(plan.Stackid.ValueString())
-	plan.Stackimage = types.StringValue(getAutoScaling.Image)
+	plan.Stackimage = types.StringValue(plan.Stackimage.ValueString())
 	plan.OsDiskSize = types.Int64Value(int64(osDiskSize * 10))
-	plan.Policies = policiesModel
+	if plan.Policies != nil {
+		plan.Policies = autoScalingPoliciesModel(plan.Policies, getAutoScaling.Policies)
+	}
+	if plan.Schedules != nil {
+		plan.Schedules = autoScalingSchedulesModel(plan.Schedules, getAutoScaling.Schedules)
+	}
 	plan.VpcID = types.StringValue(getAutoScaling.Vpc[0].ID)
+	if plan.State.IsUnknown() {
+		plan.State = types.StringValue(autoScalingState(getAutoScaling.Status))
+	}
+	plan.OutdatedInstances = types.ListValueMust(types.StringType, []attr.Value{})
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -661,27 +742,6 @@ func (s *AutoScalingResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	// Schedule
-	scheduleModel := []ScheduleModel{}
-	for _, v := range getAutoScaling.Schedules {
-		Schedule := ScheduleModel{
-			ID:          types.StringValue(v.ID),
-			Groupid:     types.StringValue(v.Groupid),
-			Name:        types.StringValue(v.Name),
-			Desiredsize: types.StringValue(v.Desiredsize),
-			Recurrence:  types.StringValue(v.Recurrence),
-			StartDate:   types.StringValue(v.StartDate),
-			Status:      types.StringValue(v.Status),
-			Timezone:    types.StringValue(v.Timezone),
-		}
-		scheduleModel = append(scheduleModel, Schedule)
-	}
-	diags = resp.State.SetAttribute(ctx, path.Root("schedules"), scheduleModel)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
 	tflog.Debug(ctx, "finish create autoscaling")
 }
 
@@ -710,33 +770,6 @@ func (s *AutoScalingResource) Read(ctx context.Context, req resource.ReadRequest
 
 	osDiskSize, _ := strconv.Atoi(getAutoScaling.Plan.Disk)
 
-	policiesModel := []PolicyModel{}
-	for _, v := range getAutoScaling.Policies {
-		policy := PolicyModel{
-			ID:                 types.StringValue(v.ID),
-			Userid:             types.StringValue(v.Userid),
-			Product:            types.StringValue(v.Product),
-			Productid:          types.StringValue(v.Productid),
-			Groupid:            types.StringValue(v.Groupid),
-			Name:               types.StringValue(v.Name),
-			Type:               types.StringValue(v.Type),
-			Adjust:             types.StringValue(v.Adjust),
-			Period:             types.StringValue(v.Period),
-			Cooldown:           types.StringValue(v.Cooldown),
-			CooldownTill:       types.StringValue(v.CooldownTill),
-			Compare:            types.StringValue(v.Compare),
-			Value:              types.StringValue(v.Value),
-			AlertID:            types.StringValue(v.AlertID),
-			Status:             types.StringValue(v.Status),
-			KubernetesID:       types.StringValue(v.KubernetesID),
-			KubernetesNodepool: types.StringValue(v.KubernetesNodepool),
-			Cloudid:            types.StringValue(v.Cloudid),
-			Maxsize:            types.StringValue(v.Maxsize),
-			Minsize:            types.StringValue(v.Minsize),
-		}
-		policiesModel = append(policiesModel, policy)
-	}
-
 	if !state.LoadbalancersID.IsNull() {
 		state.LoadbalancersID = types.StringValue(state.LoadbalancersID.ValueString())
 	}
@@ -759,9 +792,12 @@ func (s *AutoScalingResource) Read(ctx context.Context, req resource.ReadRequest
 	state.Dcslug = types.StringValue(getAutoScaling.Dcslug)
 	state.Userid = types.StringValue(getAutoScaling.Userid)
 	state.Name = types.StringValue(getAutoScaling.Name)
-	state.Minsize = types.StringValue(getAutoScaling.Minsize)
-	state.Maxsize = types.StringValue(getAutoScaling.Maxsize)
-	state.Desiredsize = types.StringValue(getAutoScaling.Desiredsize)
+	state.Minsize = types.Int64Value(helper.ParseInt64(getAutoScaling.Minsize))
+	state.Maxsize = types.Int64Value(helper.ParseInt64(getAutoScaling.Maxsize))
+	state.CurrentDesiredsize = types.Int64Value(helper.ParseInt64(getAutoScaling.Desiredsize))
+	if !state.IgnoreDesiredSize.ValueBool() {
+		state.Desiredsize = state.CurrentDesiredsize
+	}
 	state.Planid = types.StringValue(getAutoScaling.Planid)
 	state.Planname = types.StringValue(getAutoScaling.Planname)
 	state.InstanceTemplateid = types.StringValue(getAutoScaling.InstanceTemplateid)
@@ -778,10 +814,30 @@ func (s *AutoScalingResource) Read(ctx context.Context, req resource.ReadRequest
 	state.CooldownTill = types.StringValue(getAutoScaling.CooldownTill)
 	state.Backupid = types.StringValue(getAutoScaling.Backupid)
 	state.Stackid = types.StringValue(getAutoScaling.Stack)
-	state.Stackimage = types.StringValue(getAutoScaling.Image)
+	// image is the image of the running instances, it only stands in for stackimage when importing
+	if state.Stackimage.IsNull() {
+		state.Stackimage = types.StringValue(getAutoScaling.Image)
+	}
 	state.OsDiskSize = types.Int64Value(int64(osDiskSize * 10))
-	state.Policies = policiesModel
+	// only track policies and schedules managed inline, or all of them when importing
+	if state.Policies != nil || state.Name.IsNull() {
+		state.Policies = autoScalingPoliciesModel(state.Policies, getAutoScaling.Policies)
+	}
+	if state.Schedules != nil || state.Name.IsNull() {
+		state.Schedules = autoScalingSchedulesModel(state.Schedules, getAutoScaling.Schedules)
+	}
 	state.VpcID = types.StringValue(getAutoScaling.Vpc[0].ID)
+	state.State = types.StringValue(autoScalingState(getAutoScaling.Status))
+	// instances removed by scaling no longer need to be replaced
+	outdated := []string{}
+	if !state.OutdatedInstances.IsNull() {
+		resp.Diagnostics.Append(state.OutdatedInstances.ElementsAs(ctx, &outdated, false)...)
+	}
+	state.OutdatedInstances, diags = types.ListValueFrom(ctx, types.StringType, groupInstances(getAutoScaling.Instances, outdated))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -903,27 +959,6 @@ func (s *AutoScalingResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	// Schedule
-	scheduleModel := []ScheduleModel{}
-	for _, v := range getAutoScaling.Schedules {
-		Schedule := ScheduleModel{
-			ID:          types.StringValue(v.ID),
-			Groupid:     types.StringValue(v.Groupid),
-			Name:        types.StringValue(v.Name),
-			Desiredsize: types.StringValue(v.Desiredsize),
-			Recurrence:  types.StringValue(v.Recurrence),
-			StartDate:   types.StringValue(v.StartDate),
-			Status:      types.StringValue(v.Status),
-			Timezone:    types.StringValue(v.Timezone),
-		}
-		scheduleModel = append(scheduleModel, Schedule)
-	}
-	diags = resp.State.SetAttribute(ctx, path.Root("schedules"), scheduleModel)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
 	tflog.Debug(ctx, "finish get autoscaling request")
 }
 
@@ -941,17 +976,36 @@ func (s *AutoScalingResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	params := utho.UpdateAutoScalingParams{
-		AutoScalingId: state.ID.ValueString(),
-		Name:          plan.Name.ValueString(),
-		Minsize:       plan.Minsize.ValueString(),
-		Maxsize:       plan.Maxsize.ValueString(),
-		Desiredsize:   plan.Desiredsize.ValueString(),
+	desiredsize := strconv.FormatInt(plan.Desiredsize.ValueInt64(), 10)
+	if plan.IgnoreDesiredSize.ValueBool() && plan.Desiredsize.Equal(state.Desiredsize) {
+		// keep the desired size set by the platform
+		tflog.Debug(ctx, "send get autoscaling request")
+		current, err := s.client.AutoScaling().Read(state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading utho autoscaling",
+				"Could not read utho autoscaling "+state.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		desiredsize = current.Desiredsize
+	}
+
+	params := updateAutoScalingParams{
+		UpdateAutoScalingParams: utho.UpdateAutoScalingParams{
+			AutoScalingId: state.ID.ValueString(),
+			Name:          plan.Name.ValueString(),
+			Minsize:       strconv.FormatInt(plan.Minsize.ValueInt64(), 10),
+			Maxsize:       strconv.FormatInt(plan.Maxsize.ValueInt64(), 10),
+			Desiredsize:   desiredsize,
+			Stackid:       plan.Stackid.ValueString(),
+		},
+		Stackimage: plan.Stackimage.ValueString(),
 	}
 
 	tflog.Debug(ctx, "send update autoscaling request")
 	// Get refreshed autoscaling value from utho
-	_, err := s.client.AutoScaling().Update(params)
+	_, err := updateAutoScaling(s.client, params)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading utho autoscaling",
@@ -959,6 +1013,141 @@ func (s *AutoScalingResource) Update(ctx context.Context, req resource.UpdateReq
 		)
 		return
 	}
+
+	// policies are matched by name, removed ones are deleted before new ones are created
+	planPolicies := map[string]PolicyModel{}
+	for _, v := range plan.Policies {
+		planPolicies[v.Name.ValueString()] = v
+	}
+	statePolicies := map[string]PolicyModel{}
+	for _, v := range state.Policies {
+		statePolicies[v.Name.ValueString()] = v
+		if _, ok := planPolicies[v.Name.ValueString()]; ok {
+			continue
+		}
+
+		tflog.Debug(ctx, "send delete autoscaling policy request")
+		_, err := s.client.AutoScaling().DeletePolicy(v.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleteing utho autoscaling policy",
+				"Could not delete utho autoscaling policy "+v.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+	for _, v := range plan.Policies {
+		current, ok := statePolicies[v.Name.ValueString()]
+		if !ok {
+			policy := autoScalingPolicyRequest(v)
+			policy.Product = autoScalingPolicyProduct
+			policy.Productid = state.ID.ValueString()
+
+			tflog.Debug(ctx, "send create autoscaling policy request")
+			_, err := s.client.AutoScaling().CreatePolicy(policy)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error creating autoscaling policy",
+					"Could not create autoscaling policy "+v.Name.ValueString()+", unexpected error: "+err.Error(),
+				)
+				return
+			}
+			continue
+		}
+		if autoScalingPolicyRequest(v) == autoScalingPolicyRequest(current) {
+			continue
+		}
+
+		policy := autoScalingPolicyRequest(v)
+		tflog.Debug(ctx, "send update autoscaling policy request")
+		_, err := s.client.AutoScaling().UpdatePolicy(utho.UpdateAutoScalingPolicyParams{
+			AutoScalingPolicyId: current.ID.ValueString(),
+			Name:                policy.Name,
+			Type:                policy.Type,
+			Compare:             policy.Compare,
+			Value:               policy.Value,
+			Adjust:              policy.Adjust,
+			Period:              policy.Period,
+			Cooldown:            policy.Cooldown,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating utho autoscaling policy",
+				"Could not update utho autoscaling policy "+current.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// schedules are matched by name, removed ones are deleted before new ones are created
+	planSchedules := map[string]ScheduleModel{}
+	for _, v := range plan.Schedules {
+		planSchedules[v.Name.ValueString()] = v
+	}
+	stateSchedules := map[string]ScheduleModel{}
+	for _, v := range state.Schedules {
+		stateSchedules[v.Name.ValueString()] = v
+		if _, ok := planSchedules[v.Name.ValueString()]; ok {
+			continue
+		}
+
+		tflog.Debug(ctx, "send delete autoscaling schedule request")
+		_, err := s.client.AutoScaling().DeleteSchedule(state.ID.ValueString(), v.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleteing utho autoscaling schedule",
+				"Could not delete utho autoscaling schedule "+v.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+	for _, v := range plan.Schedules {
+		current, ok := stateSchedules[v.Name.ValueString()]
+		if !ok {
+			tflog.Debug(ctx, "send create autoscaling schedule request")
+			_, err := createAutoScalingSchedule(s.client, state.ID.ValueString(), autoScalingScheduleRequest(v))
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error creating autoscaling schedule",
+					"Could not create autoscaling schedule "+v.Name.ValueString()+", unexpected error: "+err.Error(),
+				)
+				return
+			}
+			continue
+		}
+		if autoScalingScheduleRequest(v) == autoScalingScheduleRequest(current) {
+			continue
+		}
+
+		tflog.Debug(ctx, "send update autoscaling schedule request")
+		_, err := updateAutoScalingSchedule(s.client, state.ID.ValueString(), current.ID.ValueString(), autoScalingScheduleRequest(v))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating utho autoscaling schedule",
+				"Could not update utho autoscaling schedule "+current.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !plan.State.IsUnknown() && !plan.State.Equal(state.State) {
+		var err error
+		if plan.State.ValueString() == autoScalingStateSuspended {
+			tflog.Debug(ctx, "send suspend autoscaling request")
+			_, err = suspendAutoScaling(s.client, state.ID.ValueString())
+		} else {
+			tflog.Debug(ctx, "send resume autoscaling request")
+			_, err = resumeAutoScaling(s.client, state.ID.ValueString())
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating utho autoscaling state",
+				"Could not set utho autoscaling "+state.ID.ValueString()+" to "+plan.State.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
 	tflog.Debug(ctx, "send get autoscaling request")
 	// Get refreshed autoscaling value from utho
 	getAutoScaling, err := s.client.AutoScaling().Read(state.ID.ValueString())
@@ -969,10 +1158,47 @@ func (s *AutoScalingResource) Update(ctx context.Context, req resource.UpdateReq
 		)
 		return
 	}
+	stackChanged := !plan.Stackid.Equal(state.Stackid) || !plan.Stackimage.Equal(state.Stackimage)
+	outdated := []string{}
+	if !state.OutdatedInstances.IsNull() {
+		resp.Diagnostics.Append(state.OutdatedInstances.ElementsAs(ctx, &outdated, false)...)
+	}
+	if stackChanged {
+		// every instance was launched before the stack change
+		outdated = []string{}
+		for _, v := range getAutoScaling.Instances {
+			outdated = append(outdated, v.ID)
+		}
+	}
+	state.Stackid = plan.Stackid
+	state.Stackimage = plan.Stackimage
+	state.State = plan.State
+	if plan.State.IsUnknown() {
+		state.State = types.StringValue(autoScalingState(getAutoScaling.Status))
+	}
+	state.InstanceRefresh = plan.InstanceRefresh
 	state.Name = types.StringValue(getAutoScaling.Name)
-	state.Minsize = types.StringValue(getAutoScaling.Minsize)
-	state.Maxsize = types.StringValue(getAutoScaling.Maxsize)
-	state.Desiredsize = types.StringValue(getAutoScaling.Desiredsize)
+	state.Minsize = types.Int64Value(helper.ParseInt64(getAutoScaling.Minsize))
+	state.Maxsize = types.Int64Value(helper.ParseInt64(getAutoScaling.Maxsize))
+	state.IgnoreDesiredSize = plan.IgnoreDesiredSize
+	state.CurrentDesiredsize = types.Int64Value(helper.ParseInt64(getAutoScaling.Desiredsize))
+	state.Desiredsize = plan.Desiredsize
+	if !plan.IgnoreDesiredSize.ValueBool() {
+		state.Desiredsize = state.CurrentDesiredsize
+	}
+	state.Policies = nil
+	if plan.Policies != nil {
+		state.Policies = autoScalingPoliciesModel(plan.Policies, getAutoScaling.Policies)
+	}
+	state.Schedules = nil
+	if plan.Schedules != nil {
+		state.Schedules = autoScalingSchedulesModel(plan.Schedules, getAutoScaling.Schedules)
+	}
+	state.OutdatedInstances, diags = types.ListValueFrom(ctx, types.StringType, outdated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -980,6 +1206,18 @@ func (s *AutoScalingResource) Update(ctx context.Context, req resource.UpdateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// the stack change is saved before the outdated instances are replaced, see instance_refresh
+	if plan.InstanceRefresh != nil && len(outdated) > 0 {
+		batchSize := plan.InstanceRefresh.BatchSize.ValueInt64()
+		if plan.InstanceRefresh.BatchSize.IsNull() {
+			batchSize = 1
+		}
+		resp.Diagnostics.Append(s.refreshInstances(ctx, &resp.State, state.ID.ValueString(), outdated, plan.Minsize.ValueInt64(), batchSize)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 	tflog.Debug(ctx, "finish get autoscaling request")
 }
 
@@ -1004,3 +1242,414 @@ func (s *AutoScalingResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 }
+
+// autoScalingSizeValidator validates that minsize <= desiredsize <= maxsize.
+type autoScalingSizeValidator struct{}
+
+func (v autoScalingSizeValidator) Description(_ context.Context) string {
+	return "desiredsize must be between minsize and maxsize"
+}
+
+func (v autoScalingSizeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v autoScalingSizeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var minsize, maxsize, desiredsize types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("minsize"), &minsize)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("maxsize"), &maxsize)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("desiredsize"), &desiredsize)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// values can only be compared once they are all known
+	for _, size := range []types.Int64{minsize, maxsize, desiredsize} {
+		if size.IsNull() || size.IsUnknown() {
+			return
+		}
+	}
+
+	if minsize.ValueInt64() > maxsize.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("minsize"),
+			"Invalid autoscaling size",
+			fmt.Sprintf("minsize (%d) must not be greater than maxsize (%d)", minsize.ValueInt64(), maxsize.ValueInt64()),
+		)
+	}
+	if desiredsize.ValueInt64() < minsize.ValueInt64() || desiredsize.ValueInt64() > maxsize.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("desiredsize"),
+			"Invalid autoscaling size",
+			fmt.Sprintf("desiredsize (%d) must be between minsize (%d) and maxsize (%d)", desiredsize.ValueInt64(), minsize.ValueInt64(), maxsize.ValueInt64()),
+		)
+	}
+}
+
+// parsePolicyPeriod converts the policy period returned by the api, eg: 5m, to minutes.
+func parsePolicyPeriod(period string) int64 {
+	period = strings.TrimSpace(period)
+	switch {
+	case strings.HasSuffix(period, "h"):
+		return helper.ParseInt64(strings.TrimSuffix(period, "h")) * 60
+	case strings.HasSuffix(period, "m"):
+		return helper.ParseInt64(strings.TrimSuffix(period, "m"))
+	default:
+		return helper.ParseInt64(period)
+	}
+}
+
+// formatPolicyPeriod converts a period in minutes to the format expected by the api.
+func formatPolicyPeriod(minutes int64) string {
+	return strconv.FormatInt(minutes, 10) + "m"
+}
+
+// autoScalingPolicyProduct is the product type of policies attached to an autoscaling group.
+const autoScalingPolicyProduct = "asg"
+
+// autoScalingPolicyRequest builds the api request body of a policy.
+func autoScalingPolicyRequest(policy PolicyModel) utho.CreateAutoScalingPolicyParams {
+	return utho.CreateAutoScalingPolicyParams{
+		Name:     policy.Name.ValueString(),
+		Type:     policy.Type.ValueString(),
+		Compare:  policy.Compare.ValueString(),
+		Value:    strconv.FormatInt(policy.Value.ValueInt64(), 10),
+		Adjust:   policy.Adjust.ValueString(),
+		Period:   formatPolicyPeriod(policy.Period.ValueInt64()),
+		Cooldown: strconv.FormatInt(policy.Cooldown.ValueInt64(), 10),
+	}
+}
+
+// autoScalingPoliciesModel converts the group policies to the model, keeping the order of the ordered policies
+// and appending policies that are not part of it.
+func autoScalingPoliciesModel(ordered []PolicyModel, policies []utho.Policy) []PolicyModel {
+	remaining := map[string]utho.Policy{}
+	for _, v := range policies {
+		remaining[v.Name] = v
+	}
+
+	policiesModel := []PolicyModel{}
+	for _, v := range ordered {
+		policy, ok := remaining[v.Name.ValueString()]
+		if !ok {
+			continue
+		}
+		delete(remaining, policy.Name)
+		policiesModel = append(policiesModel, autoScalingPolicyModel(policy))
+	}
+	for _, v := range policies {
+		if _, ok := remaining[v.Name]; ok {
+			policiesModel = append(policiesModel, autoScalingPolicyModel(v))
+		}
+	}
+
+	return policiesModel
+}
+
+func autoScalingPolicyModel(policy utho.Policy) PolicyModel {
+	return PolicyModel{
+		ID:                 types.StringValue(policy.ID),
+		Userid:             types.StringValue(policy.Userid),
+		Product:            types.StringValue(policy.Product),
+		Productid:          types.StringValue(policy.Productid),
+		Groupid:            types.StringValue(policy.Groupid),
+		Name:               types.StringValue(policy.Name),
+		Type:               types.StringValue(policy.Type),
+		Adjust:             types.StringValue(policy.Adjust),
+		Period:             types.Int64Value(parsePolicyPeriod(policy.Period)),
+		Cooldown:           types.Int64Value(helper.ParseInt64(policy.Cooldown)),
+		CooldownTill:       types.StringValue(policy.CooldownTill),
+		Compare:            types.StringValue(policy.Compare),
+		Value:              types.Int64Value(helper.ParseInt64(policy.Value)),
+		AlertID:            types.StringValue(policy.AlertID),
+		Status:             types.StringValue(policy.Status),
+		KubernetesID:       types.StringValue(policy.KubernetesID),
+		KubernetesNodepool: types.StringValue(policy.KubernetesNodepool),
+		Cloudid:            types.StringValue(policy.Cloudid),
+		Maxsize:            types.StringValue(policy.Maxsize),
+		Minsize:            types.StringValue(policy.Minsize),
+	}
+}
+
+// autoScalingScheduleRequest builds the api request body of a schedule.
+func autoScalingScheduleRequest(schedule ScheduleModel) autoScalingScheduleParams {
+	return autoScalingScheduleParams{
+		Name:        schedule.Name.ValueString(),
+		Desiredsize: strconv.FormatInt(schedule.Desiredsize.ValueInt64(), 10),
+		Recurrence:  schedule.Recurrence.ValueString(),
+		StartDate:   schedule.StartDate.ValueString(),
+		Timezone:    schedule.Timezone.ValueString(),
+	}
+}
+
+// autoScalingSchedulesModel converts the group schedules to the model, keeping the order of the ordered schedules
+// and appending schedules that are not part of it. Start date and timezone are kept as configured
+// since the api returns them in its own format.
+func autoScalingSchedulesModel(ordered []ScheduleModel, schedules []utho.Schedule) []ScheduleModel {
+	remaining := map[string]utho.Schedule{}
+	for _, v := range schedules {
+		remaining[v.Name] = v
+	}
+
+	scheduleModel := []ScheduleModel{}
+	for _, v := range ordered {
+		schedule, ok := remaining[v.Name.ValueString()]
+		if !ok {
+			continue
+		}
+		delete(remaining, schedule.Name)
+
+		model := autoScalingScheduleModel(schedule)
+		model.StartDate = v.StartDate
+		model.Timezone = v.Timezone
+		scheduleModel = append(scheduleModel, model)
+	}
+	for _, v := range schedules {
+		if _, ok := remaining[v.Name]; ok {
+			scheduleModel = append(scheduleModel, autoScalingScheduleModel(v))
+		}
+	}
+
+	return scheduleModel
+}
+
+func autoScalingScheduleModel(schedule utho.Schedule) ScheduleModel {
+	timezone := types.StringNull()
+	if schedule.Timezone != "" {
+		timezone = types.StringValue(schedule.Timezone)
+	}
+
+	return ScheduleModel{
+		ID:          types.StringValue(schedule.ID),
+		Groupid:     types.StringValue(schedule.Groupid),
+		Name:        types.StringValue(schedule.Name),
+		Desiredsize: types.Int64Value(helper.ParseInt64(schedule.Desiredsize)),
+		Recurrence:  types.StringValue(schedule.Recurrence),
+		StartDate:   types.StringValue(schedule.StartDate),
+		Status:      types.StringValue(schedule.Status),
+		Timezone:    timezone,
+	}
+}
+
+const (
+	autoScalingStateActive    = "active"
+	autoScalingStateSuspended = "suspended"
+)
+
+// autoScalingState converts the group status returned by the api to the state attribute.
+func autoScalingState(status string) string {
+	switch strings.ToLower(status) {
+	case "suspended", "stopped":
+		return autoScalingStateSuspended
+	default:
+		return autoScalingStateActive
+	}
+}
+
+// requiresReplaceWithoutInstanceRefresh replaces the group on stack changes unless instance_refresh is configured.
+func requiresReplaceWithoutInstanceRefresh(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var instanceRefresh types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("instance_refresh"), &instanceRefresh)...)
+	resp.RequiresReplace = instanceRefresh.IsNull()
+}
+
+// plannedInstanceRefresh reports whether the apply replaces group instances, either because the stack changes
+// or because an earlier refresh left outdated instances.
+func plannedInstanceRefresh(ctx context.Context, req resource.ModifyPlanRequest) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var instanceRefresh types.Object
+	var outdated types.List
+	var plannedStackid, currentStackid, plannedStackimage, currentStackimage types.String
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("instance_refresh"), &instanceRefresh)...)
+	diags.Append(req.State.GetAttribute(ctx, path.Root("outdated_instances"), &outdated)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("stackid"), &plannedStackid)...)
+	diags.Append(req.State.GetAttribute(ctx, path.Root("stackid"), &currentStackid)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("stackimage"), &plannedStackimage)...)
+	diags.Append(req.State.GetAttribute(ctx, path.Root("stackimage"), &currentStackimage)...)
+	if diags.HasError() || instanceRefresh.IsNull() {
+		return false, diags
+	}
+	stackChanged := !plannedStackid.Equal(currentStackid) || !plannedStackimage.Equal(currentStackimage)
+	return stackChanged || len(outdated.Elements()) > 0, diags
+}
+
+// validateInstanceRefreshPlan rejects an instance refresh while the group is or will be suspended,
+// a suspended group does not launch the replacement instances.
+func validateInstanceRefreshPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+	refresh, diags := plannedInstanceRefresh(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	var plannedState, currentState types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("state"), &plannedState)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("state"), &currentState)...)
+	if resp.Diagnostics.HasError() || !refresh {
+		return
+	}
+
+	if plannedState.IsUnknown() {
+		plannedState = currentState
+	}
+	if plannedState.ValueString() == autoScalingStateSuspended {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("instance_refresh"),
+			"Instance refresh of a suspended autoscaling",
+			"A suspended autoscaling does not launch replacement instances, set state to active to change stackid or stackimage with instance_refresh.",
+		)
+	}
+}
+
+// planOutdatedInstances plans no outdated instances when the apply refreshes the group, a new group has none either.
+// Otherwise the outdated instances are kept until instance_refresh is configured again.
+func planOutdatedInstances(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	outdated := types.ListValueMust(types.StringType, []attr.Value{})
+	if !req.State.Raw.IsNull() {
+		refresh, diags := plannedInstanceRefresh(ctx, req)
+		resp.Diagnostics.Append(diags...)
+		var current types.List
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("outdated_instances"), &current)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !refresh && !current.IsNull() {
+			outdated = current
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("outdated_instances"), outdated)...)
+}
+
+// autoScalingRefreshPollInterval is how often the group is read while waiting for replacement instances.
+var autoScalingRefreshPollInterval = 15 * time.Second
+
+// autoScalingRefreshTimeout is how long a single batch may take to be replaced.
+const autoScalingRefreshTimeout = 30 * time.Minute
+
+// refreshInstances replaces the outdated group instances in batches so they are launched from the current stack.
+// Each batch is deleted and the group is given time to launch replacements before the next batch starts.
+// The remaining outdated instances are saved to the state after every batch, so a later apply continues where the refresh stopped.
+func (s *AutoScalingResource) refreshInstances(ctx context.Context, state *tfsdk.State, autoScalingId string, outdated []string, minsize, batchSize int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "send get autoscaling request")
+	group, err := s.client.AutoScaling().Read(autoScalingId)
+	if err != nil {
+		diags.AddError(
+			"Error Reading utho autoscaling",
+			"Could not read utho autoscaling "+autoScalingId+": "+err.Error(),
+		)
+		return diags
+	}
+	// instances removed by scaling are not replaced
+	pending := groupInstances(group.Instances, outdated)
+	total := int64(len(group.Instances))
+	diags.Append(state.SetAttribute(ctx, path.Root("outdated_instances"), pending)...)
+
+	replaced := []string{}
+	for len(pending) > 0 {
+		size := instanceRefreshBatchSize(batchSize, total, minsize, int64(len(pending)))
+		if size < 1 {
+			diags.AddError(
+				"Error refreshing utho autoscaling instances",
+				fmt.Sprintf("Autoscaling %s runs %d instances with a minsize of %d, instances can only be replaced while the group keeps minsize instances. "+
+					"Raise desiredsize above minsize or lower minsize before changing the stack.", autoScalingId, total, minsize),
+			)
+			return diags
+		}
+		batch := pending[:size]
+
+		tflog.Info(ctx, "refresh autoscaling instances", map[string]any{"autoscaling": autoScalingId, "instances": batch, "replaced": len(replaced), "total": total})
+		for _, id := range batch {
+			tflog.Debug(ctx, "send delete cloud instance request")
+			_, err := s.client.CloudInstances().Delete(id, utho.DeleteCloudInstanceParams{Confirm: "I am aware this action will delete data and server permanently"})
+			if err != nil {
+				diags.AddError(
+					"Error refreshing utho autoscaling instances",
+					fmt.Sprintf("Could not delete instance %s of autoscaling %s: %s\n\n%s", id, autoScalingId, err.Error(), instanceRefreshProgress(replaced, pending)),
+				)
+				return diags
+			}
+		}
+
+		if err := s.waitForInstances(ctx, autoScalingId, batch, total); err != nil {
+			diags.AddError(
+				"Error refreshing utho autoscaling instances",
+				fmt.Sprintf("Replacement instances of autoscaling %s did not become active: %s\n\n%s", autoScalingId, err.Error(), instanceRefreshProgress(replaced, pending)),
+			)
+			return diags
+		}
+		replaced = append(replaced, batch...)
+		pending = pending[size:]
+		diags.Append(state.SetAttribute(ctx, path.Root("outdated_instances"), pending)...)
+		diags.AddWarning(
+			"Autoscaling instances replaced",
+			fmt.Sprintf("Autoscaling %s replaced instances %s, %d instances not replaced yet", autoScalingId, strings.Join(batch, ", "), len(pending)),
+		)
+	}
+
+	return diags
+}
+
+// instanceRefreshProgress describes which instances were replaced when a refresh stops part way.
+// The instances not replaced yet are saved as outdated_instances, the next apply replaces only those.
+func instanceRefreshProgress(replaced, pending []string) string {
+	return fmt.Sprintf("Replaced instances: %s\nInstances not replaced yet: %s\n"+
+		"The stack change was saved, the next apply replaces the instances not replaced yet.",
+		instanceList(replaced), instanceList(pending))
+}
+
+func instanceList(ids []string) string {
+	if len(ids) == 0 {
+		return "none"
+	}
+	return strings.Join(ids, ", ")
+}
+
+// groupInstances returns the ids that are still instances of the group, in the group order.
+func groupInstances(instances []utho.Instances, ids []string) []string {
+	found := []string{}
+	for _, v := range instances {
+		if slices.Contains(ids, v.ID) {
+			found = append(found, v.ID)
+		}
+	}
+	return found
+}
+
+// waitForInstances waits until the group runs count active instances that are not one of the removed instances.
+func (s *AutoScalingResource) waitForInstances(ctx context.Context, autoScalingId string, removed []string, count int64) error {
+	ctx, cancel := context.WithTimeout(ctx, autoScalingRefreshTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(autoScalingRefreshPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		group, err := s.client.AutoScaling().Read(autoScalingId)
+		if err != nil {
+			return err
+		}
+		active := int64(0)
+		for _, v := range group.Instances {
+			if strings.EqualFold(v.Status, "active") && !slices.Contains(removed, v.ID) {
+				active++
+			}
+		}
+		if active >= count {
+			return nil
+		}
+	}
+}
+
+// instanceRefreshBatchSize returns how many instances can be replaced at once without going below minsize.
+func instanceRefreshBatchSize(batchSize, instances, minsize, pending int64) int64 {
+	return min(batchSize, instances-minsize, pending)
+}
@@ -1,26 +1,37 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/uthoplatforms/utho-go/utho"
 )
 
 func TestAccAutoScalingResource(t *testing.T) {
 	resourceName := "utho_auto_scaling.example"
-
-	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-		Steps: []resource.TestStep{
-			{
-				Config: providerConfig + `
+	config := func(policyValue int) string {
+		return providerConfig + fmt.Sprintf(`
 resource "utho_auto_scaling" "example" {
 	name                = "example-name"
 	os_disk_size        = 800
 	dcslug              = "inmumbaizone2"
-	minsize             = "1"
-	maxsize             = "2"
-	desiredsize         = "1"
+	minsize             = 1
+	maxsize             = 2
+	desiredsize         = 1
+	ignore_desired_capacity_changes = true
 	planid              = "10045"
 	planname            = "basic"
 	instance_templateid = "none"
@@ -33,25 +44,57 @@ resource "utho_auto_scaling" "example" {
 		name     = "Policy-16H2jh"
 		type     = "cpu"
 		compare  = "above"
-		value    = "80"
+		value    = %d
 		adjust   = "1"
-		period   = "5m"
-		cooldown = "300"
+		period   = 5
+		cooldown = 300
+		}
+	]
+	schedules = [
+		{
+		name        = "business-hours"
+		desiredsize = 2
+		recurrence  = "daily"
+		start_date  = "2030-01-01 09:00:00"
+		timezone    = "Asia/Kolkata"
 		}
 	]
 }
-`,
+`, policyValue)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(80),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "name", "example-name"),
 					resource.TestCheckResourceAttr(resourceName, "minsize", "1"),
 					resource.TestCheckResourceAttr(resourceName, "maxsize", "2"),
 					resource.TestCheckResourceAttr(resourceName, "desiredsize", "1"),
+					resource.TestCheckResourceAttr(resourceName, "current_desiredsize", "1"),
+					resource.TestCheckResourceAttr(resourceName, "state", "active"),
 					resource.TestCheckResourceAttr(resourceName, "image", "ubuntu-22.04-x86_64"),
 					resource.TestCheckResourceAttr(resourceName, "public_ip_enabled", "true"),
 					resource.TestCheckResourceAttrSet(resourceName, "id"),
 					resource.TestCheckResourceAttrSet(resourceName, "status"),
 					resource.TestCheckResourceAttrSet(resourceName, "userid"),
 					resource.TestCheckResourceAttrSet(resourceName, "dclocation.location"),
+					resource.TestCheckResourceAttr(resourceName, "schedules.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "schedules.0.desiredsize", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "schedules.0.id"),
+				),
+			},
+			{
+				Config: config(90),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "policies.0.value", "90"),
 				),
 			},
 			{
@@ -78,3 +121,221 @@ resource "utho_auto_scaling" "example" {
 		},
 	})
 }
+
+func TestAutoScalingSizeValidator(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewAutoScalingResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	for name, tc := range map[string]struct {
+		minsize, maxsize, desiredsize int64
+		expectError                   bool
+	}{
+		"valid":             {minsize: 1, maxsize: 3, desiredsize: 2},
+		"desired below min": {minsize: 2, maxsize: 3, desiredsize: 1, expectError: true},
+		"desired above max": {minsize: 1, maxsize: 3, desiredsize: 4, expectError: true},
+		"min above max":     {minsize: 4, maxsize: 3, desiredsize: 3, expectError: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: testObject(schemaResp.Schema, map[string]tftypes.Value{
+				"minsize":     tftypes.NewValue(tftypes.Number, tc.minsize),
+				"maxsize":     tftypes.NewValue(tftypes.Number, tc.maxsize),
+				"desiredsize": tftypes.NewValue(tftypes.Number, tc.desiredsize),
+			})}
+
+			resp := &fwresource.ValidateConfigResponse{}
+			autoScalingSizeValidator{}.ValidateResource(ctx, fwresource.ValidateConfigRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Errorf("expected error %t, got %v", tc.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestAutoScalingSchedulesModel(t *testing.T) {
+	configured := []ScheduleModel{
+		{Name: types.StringValue("evening"), StartDate: types.StringValue("2030-01-01 18:00:00"), Timezone: types.StringValue("Asia/Kolkata")},
+		{Name: types.StringValue("morning"), StartDate: types.StringValue("2030-01-01 09:00:00"), Timezone: types.StringNull()},
+	}
+	schedules := []utho.Schedule{
+		{ID: "1", Name: "morning", Desiredsize: "3", Recurrence: "daily", StartDate: "2030-01-01T09:00:00Z"},
+		{ID: "2", Name: "weekend", Desiredsize: "1", Recurrence: "weekly", StartDate: "2030-01-05T00:00:00Z", Timezone: "UTC"},
+		{ID: "3", Name: "evening", Desiredsize: "2", Recurrence: "daily", StartDate: "2030-01-01T18:00:00Z", Timezone: "Asia/Kolkata"},
+	}
+
+	got := autoScalingSchedulesModel(configured, schedules)
+	var names []string
+	for _, v := range got {
+		names = append(names, v.Name.ValueString())
+	}
+	if want := []string{"evening", "morning", "weekend"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("schedule order = %v, want %v", names, want)
+	}
+	if got[1].StartDate.ValueString() != "2030-01-01 09:00:00" || !got[1].Timezone.IsNull() {
+		t.Errorf("configured start date and timezone not kept: %v, %v", got[1].StartDate, got[1].Timezone)
+	}
+	if got[1].Desiredsize.ValueInt64() != 3 || got[1].ID.ValueString() != "1" {
+		t.Errorf("schedule not read from the api: %v", got[1])
+	}
+	if got[2].StartDate.ValueString() != "2030-01-05T00:00:00Z" || got[2].Timezone.ValueString() != "UTC" {
+		t.Errorf("unmanaged schedule not read from the api: %v", got[2])
+	}
+}
+
+func TestInstanceRefreshBatchSize(t *testing.T) {
+	for name, tc := range map[string]struct {
+		batchSize, instances, minsize, pending, want int64
+	}{
+		"batch size":        {batchSize: 2, instances: 5, minsize: 1, pending: 5, want: 2},
+		"limited by min":    {batchSize: 3, instances: 3, minsize: 2, pending: 3, want: 1},
+		"limited by remain": {batchSize: 3, instances: 5, minsize: 1, pending: 1, want: 1},
+		"at minsize":        {batchSize: 1, instances: 2, minsize: 2, pending: 2, want: 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := instanceRefreshBatchSize(tc.batchSize, tc.instances, tc.minsize, tc.pending); got != tc.want {
+				t.Errorf("batch size = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInstanceRefreshProgress(t *testing.T) {
+	got := instanceRefreshProgress([]string{"1", "2"}, nil)
+	if !strings.Contains(got, "Replaced instances: 1, 2\n") || !strings.Contains(got, "Instances not replaced yet: none\n") || !strings.Contains(got, "stack change was saved") {
+		t.Errorf("progress = %q", got)
+	}
+}
+
+func TestValidateInstanceRefreshPlan(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewAutoScalingResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	object := func(stackid string, state any) tftypes.Value {
+		return instanceRefreshObject(schemaResp.Schema, stackid, state, true)
+	}
+
+	for name, tc := range map[string]struct {
+		state, plan tftypes.Value
+		expectError bool
+	}{
+		"active":           {state: object("1", autoScalingStateActive), plan: object("2", autoScalingStateActive)},
+		"resumed":          {state: object("1", autoScalingStateSuspended), plan: object("2", autoScalingStateActive)},
+		"suspended":        {state: object("1", autoScalingStateSuspended), plan: object("2", autoScalingStateSuspended), expectError: true},
+		"being suspended":  {state: object("1", autoScalingStateActive), plan: object("2", autoScalingStateSuspended), expectError: true},
+		"same stack":       {state: object("1", autoScalingStateSuspended), plan: object("1", autoScalingStateSuspended)},
+		"unknown suspends": {state: object("1", autoScalingStateSuspended), plan: object("2", tftypes.UnknownValue), expectError: true},
+		"outdated instances": {
+			state:       instanceRefreshObject(schemaResp.Schema, "1", autoScalingStateSuspended, true, "5"),
+			plan:        object("1", autoScalingStateSuspended),
+			expectError: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := fwresource.ModifyPlanRequest{
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tc.plan},
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tc.state},
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+			validateInstanceRefreshPlan(ctx, req, resp)
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Errorf("expected error %t, got %v", tc.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestPlanOutdatedInstances(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewAutoScalingResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	for name, tc := range map[string]struct {
+		state, plan tftypes.Value
+		want        []string
+	}{
+		"created":            {state: tftypes.NewValue(objectType, nil), plan: instanceRefreshObject(schemaResp.Schema, "1", autoScalingStateActive, true), want: []string{}},
+		"stack changed":      {state: instanceRefreshObject(schemaResp.Schema, "1", autoScalingStateActive, true), plan: instanceRefreshObject(schemaResp.Schema, "2", autoScalingStateActive, true), want: []string{}},
+		"refresh continues":  {state: instanceRefreshObject(schemaResp.Schema, "1", autoScalingStateActive, true, "5"), plan: instanceRefreshObject(schemaResp.Schema, "1", autoScalingStateActive, true), want: []string{}},
+		"refresh removed":    {state: instanceRefreshObject(schemaResp.Schema, "1", autoScalingStateActive, true, "5"), plan: instanceRefreshObject(schemaResp.Schema, "1", autoScalingStateActive, false), want: []string{"5"}},
+		"nothing to refresh": {state: instanceRefreshObject(schemaResp.Schema, "1", autoScalingStateActive, true), plan: instanceRefreshObject(schemaResp.Schema, "1", autoScalingStateActive, true), want: []string{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := fwresource.ModifyPlanRequest{
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tc.plan},
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tc.state},
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+			planOutdatedInstances(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var got []string
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("outdated_instances"), &got)...)
+			if resp.Diagnostics.HasError() || !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("outdated_instances = %v, want %v %v", got, tc.want, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestGroupInstances(t *testing.T) {
+	instances := []utho.Instances{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	if got := groupInstances(instances, []string{"3", "1", "4"}); !reflect.DeepEqual(got, []string{"1", "3"}) {
+		t.Errorf("groupInstances() = %v, want [1 3]", got)
+	}
+	if got := groupInstances(instances, nil); !reflect.DeepEqual(got, []string{}) {
+		t.Errorf("groupInstances() = %v, want []", got)
+	}
+}
+
+// instanceRefreshObject returns an autoscaling object with the given stack, state, instance_refresh and outdated instances.
+func instanceRefreshObject(s schema.Schema, stackid string, state any, instanceRefresh bool, outdated ...string) tftypes.Value {
+	refreshType := s.Attributes["instance_refresh"].GetType().TerraformType(context.Background()).(tftypes.Object)
+	refresh := tftypes.NewValue(refreshType, nil)
+	if instanceRefresh {
+		refresh = tftypes.NewValue(refreshType, map[string]tftypes.Value{"batch_size": tftypes.NewValue(tftypes.Number, nil)})
+	}
+	outdatedValues := []tftypes.Value{}
+	for _, id := range outdated {
+		outdatedValues = append(outdatedValues, tftypes.NewValue(tftypes.String, id))
+	}
+	return testObject(s, map[string]tftypes.Value{
+		"stackid":            tftypes.NewValue(tftypes.String, stackid),
+		"state":              tftypes.NewValue(tftypes.String, state),
+		"instance_refresh":   refresh,
+		"outdated_instances": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, outdatedValues),
+	})
+}
+
+func TestAutoScalingState(t *testing.T) {
+	for status, want := range map[string]string{
+		"Active":    autoScalingStateActive,
+		"Pending":   autoScalingStateActive,
+		"Suspended": autoScalingStateSuspended,
+		"Stopped":   autoScalingStateSuspended,
+	} {
+		if got := autoScalingState(status); got != want {
+			t.Errorf("autoScalingState(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+func TestAutoScalingUpgradeStateClearsSchedules(t *testing.T) {
+	rawState := `{"id": "1234", "minsize": "1", "schedules": [{"id": "5", "desiredsize": "2"}]}`
+	req := fwresource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(rawState)}}
+	resp := &fwresource.UpgradeStateResponse{}
+	(&AutoScalingResource{}).UpgradeState(context.Background())[0].StateUpgrader(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(resp.DynamicValue.JSON, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["schedules"] != nil || got["minsize"] != float64(1) {
+		t.Errorf("upgraded state = %v, want minsize 1 and no schedules", got)
+	}
+}
@@ -0,0 +1,274 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// implement resource interfaces.
+var (
+	_ resource.Resource                = &AutoScalingScheduleResource{}
+	_ resource.ResourceWithConfigure   = &AutoScalingScheduleResource{}
+	_ resource.ResourceWithImportState = &AutoScalingScheduleResource{}
+)
+
+// NewAutoScalingScheduleResource is a helper function to simplify the provider implementation.
+func NewAutoScalingScheduleResource() resource.Resource {
+	return &AutoScalingScheduleResource{}
+}
+
+// AutoScalingScheduleResource is the resource implementation.
+type AutoScalingScheduleResource struct {
+	client utho.Client
+}
+
+type AutoScalingScheduleResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	GroupID     types.String `tfsdk:"group_id"`
+	Name        types.String `tfsdk:"name"`
+	Desiredsize types.Int64  `tfsdk:"desiredsize"`
+	Recurrence  types.String `tfsdk:"recurrence"`
+	StartDate   types.String `tfsdk:"start_date"`
+	Timezone    types.String `tfsdk:"timezone"`
+	Status      types.String `tfsdk:"status"`
+}
+
+// Metadata returns the resource type name.
+func (s *AutoScalingScheduleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auto_scaling_schedule"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *AutoScalingScheduleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(utho.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected AutoScalingSchedule Data Source Configure Type",
+			fmt.Sprintf("Expected utho.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// Schema defines the schema for the resource.
+func (s *AutoScalingScheduleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":       schema.StringAttribute{Computed: true, Description: "id", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"group_id": schema.StringAttribute{Required: true, Description: "Id of the autoscaling group", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"name":     schema.StringAttribute{Required: true, Description: "Schedule name, unique within the group"},
+			"desiredsize": schema.Int64Attribute{Required: true, Description: "Number of instances the group should run once the schedule triggers",
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"recurrence": schema.StringAttribute{Required: true, Description: "How often the schedule triggers eg: once, daily, weekly, monthly"},
+			"start_date": schema.StringAttribute{Required: true, Description: "Date and time the schedule first triggers eg: 2024-07-01 10:00:00"},
+			"timezone":   schema.StringAttribute{Optional: true, Description: "Timezone of start_date eg: Asia/Kolkata"},
+			"status":     schema.StringAttribute{Computed: true, Description: "status"},
+		},
+	}
+}
+
+// Import using group_id/schedule_id as the attribute
+func (s *AutoScalingScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "/")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: group_id/schedule_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+}
+
+// Create a new resource.
+func (s *AutoScalingScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "create autoscaling schedule")
+	// Retrieve values from plan
+	var plan AutoScalingScheduleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "send create autoscaling schedule request")
+	_, err := createAutoScalingSchedule(s.client, plan.GroupID.ValueString(), plan.request())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating autoscaling schedule",
+			"Could not create autoscaling schedule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// the create response does not always contain the schedule id, look it up by name
+	schedules, err := s.client.AutoScaling().ListSchedules(plan.GroupID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho autoscaling schedule",
+			"Could not read utho autoscaling schedules of group "+plan.GroupID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	var schedule *utho.Schedule
+	for i := range schedules {
+		if schedules[i].Name == plan.Name.ValueString() {
+			schedule = &schedules[i]
+		}
+	}
+	if schedule == nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho autoscaling schedule",
+			"Could not find the created autoscaling schedule "+plan.Name.ValueString()+" in group "+plan.GroupID.ValueString(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = types.StringValue(schedule.ID)
+	plan.Status = types.StringValue(schedule.Status)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish create autoscaling schedule")
+}
+
+// Read resource information.
+func (s *AutoScalingScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "read autoscaling schedule")
+
+	// Get current state
+	var state AutoScalingScheduleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "send get autoscaling schedule request")
+	// Get refreshed schedule value from utho
+	schedule, err := s.client.AutoScaling().ReadSchedule(state.GroupID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho autoscaling schedule",
+			"Could not read utho autoscaling schedule "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Overwrite items with refreshed state, start date and timezone are kept as configured
+	// since the api returns them in its own format
+	model := autoScalingScheduleModel(*schedule)
+	state.Name = model.Name
+	state.Desiredsize = model.Desiredsize
+	state.Recurrence = model.Recurrence
+	state.Status = model.Status
+	if state.StartDate.IsNull() {
+		state.StartDate = model.StartDate
+		state.Timezone = model.Timezone
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish get autoscaling schedule request")
+}
+
+func (s *AutoScalingScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "update autoscaling schedule")
+	var plan AutoScalingScheduleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "send update autoscaling schedule request")
+	_, err := updateAutoScalingSchedule(s.client, plan.GroupID.ValueString(), plan.ID.ValueString(), plan.request())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating utho autoscaling schedule",
+			"Could not update utho autoscaling schedule "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	schedule, err := s.client.AutoScaling().ReadSchedule(plan.GroupID.ValueString(), plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho autoscaling schedule",
+			"Could not read utho autoscaling schedule "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	plan.Status = types.StringValue(schedule.Status)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish update autoscaling schedule")
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (s *AutoScalingScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "delete autoscaling schedule")
+	// Get current state
+	var state AutoScalingScheduleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "send delete autoscaling schedule request")
+	// delete autoscaling schedule
+	_, err := s.client.AutoScaling().DeleteSchedule(state.GroupID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleteing utho autoscaling schedule",
+			"Could not delete utho autoscaling schedule "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// request builds the api request body of the schedule.
+func (m AutoScalingScheduleResourceModel) request() autoScalingScheduleParams {
+	return autoScalingScheduleRequest(ScheduleModel{
+		Name:        m.Name,
+		Desiredsize: m.Desiredsize,
+		Recurrence:  m.Recurrence,
+		StartDate:   m.StartDate,
+		Timezone:    m.Timezone,
+	})
+}
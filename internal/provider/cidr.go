@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// VPCs and subnets are ipv4 ranges, the api takes them as a network address and a prefix size.
+
+// VPC ranges are private ranges with a prefix size between vpcMinPrefixSize and vpcMaxPrefixSize.
+const (
+	vpcMinPrefixSize = 16
+	vpcMaxPrefixSize = 28
+)
+
+// privateRanges are the RFC 1918 private ipv4 ranges.
+var privateRanges = []netip.Prefix{
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+}
+
+// errNoFreeCIDR is returned when every block of the supernet overlaps a used range.
+var errNoFreeCIDR = errors.New("no free range")
+
+// parseCIDR returns the ipv4 range of a cidr, the address must be the network address of the range.
+func parseCIDR(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%q is not a cidr eg: 10.210.100.0/24", cidr)
+	}
+	if !prefix.Addr().Is4() {
+		return netip.Prefix{}, fmt.Errorf("%q is not an ipv4 range", cidr)
+	}
+	if prefix.Masked() != prefix {
+		return netip.Prefix{}, fmt.Errorf("%q is not the network address of the range, use %s", cidr, prefix.Masked())
+	}
+	return prefix, nil
+}
+
+// networkCIDR returns the range of a network address and prefix size as the api returns them.
+func networkCIDR(network, size string) (netip.Prefix, error) {
+	return parseCIDR(network + "/" + size)
+}
+
+// splitCIDR returns the network address and prefix size of a range as the api takes them.
+func splitCIDR(prefix netip.Prefix) (network, size string) {
+	return prefix.Addr().String(), strconv.Itoa(prefix.Bits())
+}
+
+// cidrContains reports whether inner lies within outer.
+func cidrContains(outer, inner netip.Prefix) bool {
+	return outer.Bits() <= inner.Bits() && outer.Contains(inner.Addr())
+}
+
+// isPrivateCIDR reports whether the range lies within one of the RFC 1918 private ranges.
+func isPrivateCIDR(prefix netip.Prefix) bool {
+	for _, private := range privateRanges {
+		if cidrContains(private, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextFreeCIDR returns the first block with the prefix size in the supernet that does not overlap a used range.
+func nextFreeCIDR(supernet netip.Prefix, bits int, used []netip.Prefix) (netip.Prefix, error) {
+	if bits < supernet.Bits() || bits > 32 {
+		return netip.Prefix{}, fmt.Errorf("a /%d block does not fit in %s", bits, supernet)
+	}
+	step := uint64(1) << (32 - bits)
+	end := prefixStart(supernet) + uint64(1)<<(32-supernet.Bits())
+	for start := prefixStart(supernet); start < end; {
+		candidate := netip.PrefixFrom(addrFrom(start), bits)
+		free, next := true, start+step
+		for _, u := range used {
+			if u.Overlaps(candidate) {
+				// skip past the used range, rounded up to the next block
+				free = false
+				usedEnd := prefixStart(u.Masked()) + uint64(1)<<(32-u.Bits())
+				next = max(next, (usedEnd+step-1)/step*step)
+			}
+		}
+		if free {
+			return candidate, nil
+		}
+		start = next
+	}
+	return netip.Prefix{}, fmt.Errorf("%w of size /%d in %s", errNoFreeCIDR, bits, supernet)
+}
+
+func prefixStart(prefix netip.Prefix) uint64 {
+	a := prefix.Addr().As4()
+	return uint64(binary.BigEndian.Uint32(a[:]))
+}
+
+func addrFrom(n uint64) netip.Addr {
+	var a [4]byte
+	binary.BigEndian.PutUint32(a[:], uint32(n))
+	return netip.AddrFrom4(a)
+}
+
+var _ validator.String = cidrValidator{}
+
+// cidrValidator checks that a string is an ipv4 cidr starting at its network address.
+type cidrValidator struct{}
+
+func (v cidrValidator) Description(_ context.Context) string {
+	return "value must be an ipv4 cidr eg: 10.210.100.0/24"
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := parseCIDR(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid cidr", err.Error())
+	}
+}
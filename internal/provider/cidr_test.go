@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestParseCIDR(t *testing.T) {
+	for cidr, wantErr := range map[string]bool{
+		"10.210.100.0/24":  false,
+		"172.16.0.0/12":    false,
+		"10.210.100.1/24":  true,
+		"10.210.100.0":     true,
+		"2401:db00::/64":   true,
+		"10.210.100.0/33":  true,
+		"example.com/24":   true,
+		"192.168.10.64/26": false,
+	} {
+		if _, err := parseCIDR(cidr); (err != nil) != wantErr {
+			t.Errorf("parseCIDR(%q) error = %v, want error %t", cidr, err, wantErr)
+		}
+	}
+
+	prefix, err := networkCIDR("10.210.100.0", "24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if network, size := splitCIDR(prefix); network != "10.210.100.0" || size != "24" {
+		t.Errorf("splitCIDR() = %s, %s, want 10.210.100.0, 24", network, size)
+	}
+}
+
+func TestCidrContains(t *testing.T) {
+	vpc := netip.MustParsePrefix("10.210.100.0/24")
+	for cidr, want := range map[string]bool{
+		"10.210.100.0/24":  true,
+		"10.210.100.64/26": true,
+		"10.210.0.0/16":    false,
+		"10.210.101.0/26":  false,
+	} {
+		if got := cidrContains(vpc, netip.MustParsePrefix(cidr)); got != want {
+			t.Errorf("cidrContains(%s, %s) = %t, want %t", vpc, cidr, got, want)
+		}
+	}
+}
+
+func TestNextFreeCIDR(t *testing.T) {
+	supernet := netip.MustParsePrefix("10.210.0.0/16")
+	for name, tc := range map[string]struct {
+		bits int
+		used []string
+		want string
+	}{
+		"empty supernet":       {bits: 24, want: "10.210.0.0/24"},
+		"after a used block":   {bits: 24, used: []string{"10.210.0.0/24"}, want: "10.210.1.0/24"},
+		"gap between blocks":   {bits: 24, used: []string{"10.210.0.0/24", "10.210.2.0/24"}, want: "10.210.1.0/24"},
+		"smaller used block":   {bits: 24, used: []string{"10.210.0.128/25"}, want: "10.210.1.0/24"},
+		"larger used block":    {bits: 26, used: []string{"10.210.0.0/20"}, want: "10.210.16.0/26"},
+		"used outside":         {bits: 24, used: []string{"10.211.0.0/16"}, want: "10.210.0.0/24"},
+		"covering used block":  {bits: 24, used: []string{"10.0.0.0/8"}},
+		"block larger than it": {bits: 12},
+	} {
+		t.Run(name, func(t *testing.T) {
+			used := []netip.Prefix{}
+			for _, cidr := range tc.used {
+				used = append(used, netip.MustParsePrefix(cidr))
+			}
+			got, err := nextFreeCIDR(supernet, tc.bits, used)
+			if tc.want == "" {
+				if err == nil {
+					t.Fatalf("nextFreeCIDR() = %s, want an error", got)
+				}
+				return
+			}
+			if err != nil || got.String() != tc.want {
+				t.Fatalf("nextFreeCIDR() = %s, %v, want %s", got, err, tc.want)
+			}
+		})
+	}
+
+	if !isPrivateCIDR(netip.MustParsePrefix("172.16.0.0/12")) || isPrivateCIDR(netip.MustParsePrefix("172.32.0.0/16")) {
+		t.Error("isPrivateCIDR() should only accept RFC 1918 ranges")
+	}
+}
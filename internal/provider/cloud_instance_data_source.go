@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// NewCloudInstanceDataSource is a helper function to simplify the provider implementation.
+// The cloud instance is looked up by id or by name, optionally within a zone.
+func NewCloudInstanceDataSource() datasource.DataSource {
+	return &resourceDataSource{
+		resource:    NewCloudInstanceResource,
+		idAttribute: "id",
+		lookup:      lookupCloudInstance,
+		dcslug:      true,
+	}
+}
+
+func lookupCloudInstance(client utho.Client, name, dcslug string) ([]string, error) {
+	cloudInstances, err := client.CloudInstances().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, cloudInstance := range cloudInstances {
+		if cloudInstance.Hostname == name && (dcslug == "" || cloudInstance.Dclocation.Dc == dcslug) {
+			ids = append(ids, cloudInstance.ID)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,318 @@
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// The ipv6 address, additional public ips and additional vpcs of a cloud instance are changed in place.
+// Unset attributes are not managed, the instance keeps the networks it has.
+
+const (
+	networkActionEnableIPv6     = "enableipv6"
+	networkActionDisableIPv6    = "disableipv6"
+	networkActionAssignPublicIP = "assignpublicip"
+	networkActionRemovePublicIP = "removepublicip"
+	networkActionAttachVpc      = "attachvpc"
+	networkActionDetachVpc      = "detachvpc"
+)
+
+// cloudInstanceNetworkChange is an action changing the networks of an instance,
+// target is the removed ip or the attached or detached vpc.
+type cloudInstanceNetworkChange struct {
+	action string
+	target string
+}
+
+func (c cloudInstanceNetworkChange) apply(client utho.Client, instanceId string) error {
+	var err error
+	switch c.action {
+	case networkActionEnableIPv6:
+		_, err = enableCloudInstanceIPv6(client, instanceId)
+	case networkActionDisableIPv6:
+		_, err = disableCloudInstanceIPv6(client, instanceId)
+	case networkActionAssignPublicIP:
+		_, err = client.CloudInstances().AssignPublicIP(instanceId)
+	case networkActionRemovePublicIP:
+		_, err = removeCloudInstancePublicIP(client, instanceId, c.target)
+	case networkActionAttachVpc:
+		_, err = attachCloudInstanceVpc(client, instanceId, c.target)
+	case networkActionDetachVpc:
+		_, err = detachCloudInstanceVpc(client, instanceId, c.target)
+	}
+	return err
+}
+
+// cloudInstanceNetworkChanges returns the actions that give the instance the configured networks.
+func cloudInstanceNetworkChanges(cloudinstance *cloudInstance, ipv6Enabled types.Bool, additionalPublicIps types.Int64, additionalVpcIds types.Set) []cloudInstanceNetworkChange {
+	var changes []cloudInstanceNetworkChange
+
+	if !ipv6Enabled.IsNull() && !ipv6Enabled.IsUnknown() && ipv6Enabled.ValueBool() != (len(cloudinstance.Networks.Public.V6) > 0) {
+		action := networkActionDisableIPv6
+		if ipv6Enabled.ValueBool() {
+			action = networkActionEnableIPv6
+		}
+		changes = append(changes, cloudInstanceNetworkChange{action: action})
+	}
+
+	if !additionalPublicIps.IsNull() && !additionalPublicIps.IsUnknown() {
+		ips := cloudInstanceAdditionalPublicIPs(cloudinstance)
+		for n := int64(len(ips)); n < additionalPublicIps.ValueInt64(); n++ {
+			changes = append(changes, cloudInstanceNetworkChange{action: networkActionAssignPublicIP})
+		}
+		for n := int64(len(ips)); n > additionalPublicIps.ValueInt64(); n-- {
+			changes = append(changes, cloudInstanceNetworkChange{action: networkActionRemovePublicIP, target: ips[n-1]})
+		}
+	}
+
+	if !additionalVpcIds.IsNull() && !additionalVpcIds.IsUnknown() {
+		current := cloudInstanceAdditionalVpcIDs(cloudinstance)
+		configured := setStrings(additionalVpcIds)
+		for _, vpcId := range configured {
+			if !slices.Contains(current, vpcId) {
+				changes = append(changes, cloudInstanceNetworkChange{action: networkActionAttachVpc, target: vpcId})
+			}
+		}
+		for _, vpcId := range current {
+			if !slices.Contains(configured, vpcId) {
+				changes = append(changes, cloudInstanceNetworkChange{action: networkActionDetachVpc, target: vpcId})
+			}
+		}
+	}
+
+	return changes
+}
+
+// applyNetworkChanges applies the changes and returns the instance with its new networks.
+func (s *CloudInstanceResource) applyNetworkChanges(ctx context.Context, instanceId string, changes []cloudInstanceNetworkChange) (*cloudInstance, error) {
+	for _, change := range changes {
+		tflog.Debug(ctx, "send cloud instance network request", map[string]any{"action": change.action, "target": change.target})
+		if err := change.apply(s.client, instanceId); err != nil {
+			return nil, err
+		}
+	}
+	return readCloudInstance(s.client, instanceId)
+}
+
+// updateNetworks applies the planned ipv6, additional public ips and additional vpcs to the instance.
+func (s *CloudInstanceResource) updateNetworks(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CloudInstanceResourceModel
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &plan.ID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ipv6_enabled"), &plan.Ipv6Enabled)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("additional_public_ips"), &plan.AdditionalPublicIps)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("additional_vpc_ids"), &plan.AdditionalVpcIds)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cloudinstance, err := readCloudInstance(s.client, plan.ID.ValueString())
+	if err == nil {
+		changes := cloudInstanceNetworkChanges(cloudinstance, plan.Ipv6Enabled, plan.AdditionalPublicIps, plan.AdditionalVpcIds)
+		cloudinstance, err = s.applyNetworkChanges(ctx, plan.ID.ValueString(), changes)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating utho cloud instance networks",
+			"Could not update the networks of utho cloud instance "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ipv6_enabled"), plan.Ipv6Enabled)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("additional_public_ips"), plan.AdditionalPublicIps)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("additional_vpc_ids"), plan.AdditionalVpcIds)...)
+	resp.Diagnostics.Append(setCloudInstanceNetworkState(ctx, &resp.State, cloudinstance)...)
+}
+
+// cloudInstanceNetworksChanged returns whether the planned ipv6, additional public ips or additional vpcs differ from the state.
+func cloudInstanceNetworksChanged(ctx context.Context, req resource.UpdateRequest) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	for _, name := range []string{"ipv6_enabled", "additional_public_ips", "additional_vpc_ids"} {
+		var planned, current attr.Value
+		diags.Append(req.Plan.GetAttribute(ctx, path.Root(name), &planned)...)
+		diags.Append(req.State.GetAttribute(ctx, path.Root(name), &current)...)
+		if diags.HasError() {
+			return false, diags
+		}
+		if !planned.Equal(current) {
+			return true, diags
+		}
+	}
+	return false, diags
+}
+
+// setCloudInstanceNetworkState sets the public and private networks of the instance and its primary addresses.
+func setCloudInstanceNetworkState(ctx context.Context, state *tfsdk.State, cloudinstance *cloudInstance) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// PrivateNetwork
+	var privateNetworkObjType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"noip":       types.Int64Type,
+		"ip_address": types.StringType,
+		"vpc_name":   types.StringType,
+		"network":    types.StringType,
+		"vpc_id":     types.StringType,
+		"netmask":    types.StringType,
+		"gateway":    types.StringType,
+		"type":       types.StringType,
+		"primary":    types.StringType,
+	}}
+	privateNetworkModel := make([]PrivateNetworkResourceModel, len(cloudinstance.Networks.Private.V4))
+	for i, v := range cloudinstance.Networks.Private.V4 {
+		privateNetworkModel[i] = PrivateNetworkResourceModel{
+			Noip:      types.Int64Value(int64(v.Noip)),
+			IPAddress: types.StringValue(v.IPAddress),
+			VpcName:   types.StringValue(v.VpcName),
+			Network:   types.StringValue(v.Network),
+			VpcID:     types.StringValue(v.VpcID),
+			Netmask:   types.StringValue(v.Netmask),
+			Gateway:   types.StringValue(v.Gateway),
+			Type:      types.StringValue(v.Type),
+			Primary:   types.StringValue(v.Primary),
+		}
+	}
+	privateNetworkList, d := types.ListValueFrom(ctx, privateNetworkObjType, privateNetworkModel)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(state.SetAttribute(ctx, path.Root("private_network"), privateNetworkList)...)
+
+	// PublicNetwork, ipv4 addresses then ipv6 addresses
+	var publicNetworkObjType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"ip_address": types.StringType,
+		"netmask":    types.StringType,
+		"gateway":    types.StringType,
+		"type":       types.StringType,
+		"nat":        types.BoolType,
+		"primary":    types.StringType,
+	}}
+	publicNetworkModel := make([]PublicNetworkResourceModel, 0, len(cloudinstance.Networks.Public.V4)+len(cloudinstance.Networks.Public.V6))
+	for _, v := range cloudinstance.Networks.Public.V4 {
+		publicNetworkModel = append(publicNetworkModel, PublicNetworkResourceModel{
+			IPAddress: types.StringValue(v.IPAddress),
+			Netmask:   types.StringValue(v.Netmask),
+			Gateway:   types.StringValue(v.Gateway),
+			Type:      types.StringValue(v.Type),
+			Nat:       types.BoolValue(v.Nat),
+			Primary:   types.StringValue(v.Primary),
+		})
+	}
+	for _, v := range cloudinstance.Networks.Public.V6 {
+		publicNetworkModel = append(publicNetworkModel, PublicNetworkResourceModel{
+			IPAddress: types.StringValue(v.IPAddress),
+			Netmask:   types.StringValue(v.Netmask),
+			Gateway:   types.StringValue(v.Gateway),
+			Type:      types.StringValue(v.Type),
+			Nat:       types.BoolValue(false),
+			Primary:   types.StringValue(v.Primary),
+		})
+	}
+	publicNetworkList, d := types.ListValueFrom(ctx, publicNetworkObjType, publicNetworkModel)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(state.SetAttribute(ctx, path.Root("public_network"), publicNetworkList)...)
+
+	diags.Append(state.SetAttribute(ctx, path.Root("ipv4_private"), types.StringValue(cloudInstancePrivateIPv4(cloudinstance)))...)
+	diags.Append(state.SetAttribute(ctx, path.Root("ipv6_address"), types.StringValue(cloudInstanceIPv6(cloudinstance)))...)
+	return diags
+}
+
+// cloudInstanceAdditionalPublicIPs returns the public ipv4 addresses of the instance besides its primary ip.
+func cloudInstanceAdditionalPublicIPs(cloudinstance *cloudInstance) []string {
+	var ips []string
+	for _, v := range cloudinstance.Networks.Public.V4 {
+		if v.Primary != "1" {
+			ips = append(ips, v.IPAddress)
+		}
+	}
+	return ips
+}
+
+// cloudInstanceAdditionalVpcIDs returns the vpcs the instance is attached to besides its primary vpc.
+func cloudInstanceAdditionalVpcIDs(cloudinstance *cloudInstance) []string {
+	var vpcIds []string
+	for _, v := range cloudinstance.Networks.Private.V4 {
+		if v.Primary != "1" && v.VpcID != "" && !slices.Contains(vpcIds, v.VpcID) {
+			vpcIds = append(vpcIds, v.VpcID)
+		}
+	}
+	return vpcIds
+}
+
+// cloudInstancePrivateIPv4 returns the primary private ipv4 address of the instance, empty without private network.
+func cloudInstancePrivateIPv4(cloudinstance *cloudInstance) string {
+	return primaryAddress(cloudinstance.Networks.Private.V4, func(v utho.V4Private) (string, string) { return v.IPAddress, v.Primary })
+}
+
+// cloudInstanceIPv6 returns the primary ipv6 address of the instance, empty without ipv6.
+func cloudInstanceIPv6(cloudinstance *cloudInstance) string {
+	return primaryAddress(cloudinstance.Networks.Public.V6, func(v cloudInstanceV6) (string, string) { return v.IPAddress, v.Primary })
+}
+
+// primaryAddress returns the address of the primary network, or of the first network when none is marked primary.
+func primaryAddress[T any](networks []T, address func(T) (ip, primary string)) string {
+	for _, network := range networks {
+		if ip, primary := address(network); primary == "1" {
+			return ip
+		}
+	}
+	if len(networks) > 0 {
+		ip, _ := address(networks[0])
+		return ip
+	}
+	return ""
+}
+
+// setStrings returns the known strings of the set.
+func setStrings(set types.Set) []string {
+	var values []string
+	for _, element := range set.Elements() {
+		if value, ok := element.(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+			values = append(values, value.ValueString())
+		}
+	}
+	return values
+}
+
+var _ resource.ConfigValidator = cloudInstanceVpcValidator{}
+
+// cloudInstanceVpcValidator checks that the primary vpc is not also an additional vpc.
+type cloudInstanceVpcValidator struct{}
+
+func (v cloudInstanceVpcValidator) Description(_ context.Context) string {
+	return "additional_vpc_ids must not contain vpc_id"
+}
+
+func (v cloudInstanceVpcValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cloudInstanceVpcValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var vpcId types.String
+	var additionalVpcIds types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("vpc_id"), &vpcId)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("additional_vpc_ids"), &additionalVpcIds)...)
+	if resp.Diagnostics.HasError() || vpcId.IsNull() || vpcId.IsUnknown() {
+		return
+	}
+
+	if slices.Contains(setStrings(additionalVpcIds), vpcId.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("additional_vpc_ids"),
+			"Invalid additional_vpc_ids",
+			"The primary vpc "+vpcId.ValueString()+" of vpc_id can not also be an additional vpc",
+		)
+	}
+}
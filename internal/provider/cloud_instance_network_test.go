@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+func testCloudInstanceWithNetworks() *cloudInstance {
+	cloudinstance := &cloudInstance{}
+	cloudinstance.Networks.Public.V4 = utho.V4PublicArray{
+		{IPAddress: "103.0.0.1", Primary: "1"},
+		{IPAddress: "103.0.0.2", Primary: "0"},
+		{IPAddress: "103.0.0.3", Primary: "0"},
+	}
+	cloudinstance.Networks.Private.V4 = []utho.V4Private{
+		{IPAddress: "10.0.0.5", VpcID: "vpc-primary", Primary: "1"},
+		{IPAddress: "10.1.0.5", VpcID: "vpc-a", Primary: "0"},
+	}
+	return cloudinstance
+}
+
+func TestCloudInstanceNetworkChanges(t *testing.T) {
+	vpcs := func(ids ...string) types.Set {
+		values := make([]attr.Value, len(ids))
+		for i, id := range ids {
+			values[i] = types.StringValue(id)
+		}
+		return types.SetValueMust(types.StringType, values)
+	}
+
+	for name, tc := range map[string]struct {
+		ipv6Enabled         types.Bool
+		additionalPublicIps types.Int64
+		additionalVpcIds    types.Set
+		want                []cloudInstanceNetworkChange
+	}{
+		"unmanaged": {
+			ipv6Enabled: types.BoolNull(), additionalPublicIps: types.Int64Null(), additionalVpcIds: types.SetNull(types.StringType),
+		},
+		"unchanged": {
+			ipv6Enabled: types.BoolValue(false), additionalPublicIps: types.Int64Value(2), additionalVpcIds: vpcs("vpc-a"),
+		},
+		"enable ipv6": {
+			ipv6Enabled: types.BoolValue(true), additionalPublicIps: types.Int64Null(), additionalVpcIds: types.SetNull(types.StringType),
+			want: []cloudInstanceNetworkChange{{action: networkActionEnableIPv6}},
+		},
+		"more public ips": {
+			ipv6Enabled: types.BoolNull(), additionalPublicIps: types.Int64Value(4), additionalVpcIds: types.SetNull(types.StringType),
+			want: []cloudInstanceNetworkChange{{action: networkActionAssignPublicIP}, {action: networkActionAssignPublicIP}},
+		},
+		"fewer public ips": {
+			ipv6Enabled: types.BoolNull(), additionalPublicIps: types.Int64Value(0), additionalVpcIds: types.SetNull(types.StringType),
+			want: []cloudInstanceNetworkChange{
+				{action: networkActionRemovePublicIP, target: "103.0.0.3"},
+				{action: networkActionRemovePublicIP, target: "103.0.0.2"},
+			},
+		},
+		"swap vpc": {
+			ipv6Enabled: types.BoolNull(), additionalPublicIps: types.Int64Null(), additionalVpcIds: vpcs("vpc-b"),
+			want: []cloudInstanceNetworkChange{
+				{action: networkActionAttachVpc, target: "vpc-b"},
+				{action: networkActionDetachVpc, target: "vpc-a"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got := cloudInstanceNetworkChanges(testCloudInstanceWithNetworks(), tc.ipv6Enabled, tc.additionalPublicIps, tc.additionalVpcIds)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("changes = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCloudInstancePrimaryAddresses(t *testing.T) {
+	cloudinstance := testCloudInstanceWithNetworks()
+	if got := cloudInstancePrivateIPv4(cloudinstance); got != "10.0.0.5" {
+		t.Errorf("ipv4_private = %q, want 10.0.0.5", got)
+	}
+	if got := cloudInstanceIPv6(cloudinstance); got != "" {
+		t.Errorf("ipv6_address = %q, want empty", got)
+	}
+
+	cloudinstance.Networks.Public.V6 = []cloudInstanceV6{{IPAddress: "2401:db00::1"}, {IPAddress: "2401:db00::2"}}
+	if got := cloudInstanceIPv6(cloudinstance); got != "2401:db00::1" {
+		t.Errorf("ipv6_address = %q, want the first address", got)
+	}
+}
+
+func TestCloudInstanceVpcValidator(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewCloudInstanceResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	for name, tc := range map[string]struct {
+		vpcId            any
+		additionalVpcIds []tftypes.Value
+		expectError      bool
+	}{
+		"no vpcs":         {},
+		"additional vpcs": {vpcId: "vpc-primary", additionalVpcIds: []tftypes.Value{tftypes.NewValue(tftypes.String, "vpc-a")}},
+		"primary vpc":     {vpcId: "vpc-primary", additionalVpcIds: []tftypes.Value{tftypes.NewValue(tftypes.String, "vpc-primary")}, expectError: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			overrides := map[string]tftypes.Value{"vpc_id": tftypes.NewValue(tftypes.String, tc.vpcId)}
+			if tc.additionalVpcIds != nil {
+				overrides["additional_vpc_ids"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tc.additionalVpcIds)
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: testObject(schemaResp.Schema, overrides)}
+
+			resp := &fwresource.ValidateConfigResponse{}
+			cloudInstanceVpcValidator{}.ValidateResource(ctx, fwresource.ValidateConfigRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Errorf("expected error %t, got %v", tc.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// A cloud instance can boot a mounted iso or the rescue system to recover its disk,
+// both are applied in place. Removing mounted_iso_id unmounts the iso and removing rescue_mode leaves rescue mode.
+
+// applyRecovery mounts the planned iso and enters or leaves rescue mode, the current values are the ones in the state.
+func applyRecovery(ctx context.Context, client utho.Client, instanceId string, mountedIsoId, currentMountedIsoId types.String, rescueMode, currentRescueMode types.Bool) error {
+	if !mountedIsoId.Equal(currentMountedIsoId) {
+		if currentMountedIsoId.ValueString() != "" {
+			tflog.Debug(ctx, "send unmount iso request")
+			if _, err := client.CloudInstances().UnmountISO(instanceId); err != nil {
+				return err
+			}
+		}
+		if mountedIsoId.ValueString() != "" {
+			tflog.Debug(ctx, "send mount iso request")
+			if _, err := client.CloudInstances().MountISO(instanceId, utho.MountISOParams{Iso: mountedIsoId.ValueString()}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if rescueMode.ValueBool() != currentRescueMode.ValueBool() {
+		var err error
+		if rescueMode.ValueBool() {
+			tflog.Debug(ctx, "send enable rescue request")
+			_, err = client.CloudInstances().EnableRescue(instanceId)
+		} else {
+			tflog.Debug(ctx, "send disable rescue request")
+			_, err = client.CloudInstances().DisableRescue(instanceId)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateRecovery applies the planned mounted_iso_id and rescue_mode when they changed.
+func (s *CloudInstanceResource) updateRecovery(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var id, mountedIsoId, currentMountedIsoId types.String
+	var rescueMode, currentRescueMode types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("mounted_iso_id"), &mountedIsoId)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("mounted_iso_id"), &currentMountedIsoId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rescue_mode"), &rescueMode)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("rescue_mode"), &currentRescueMode)...)
+	if resp.Diagnostics.HasError() || (mountedIsoId.Equal(currentMountedIsoId) && rescueMode.Equal(currentRescueMode)) {
+		return
+	}
+
+	if err := applyRecovery(ctx, s.client, id.ValueString(), mountedIsoId, currentMountedIsoId, rescueMode, currentRescueMode); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating utho cloud instance recovery",
+			"Could not change the iso or rescue mode of utho cloud instance "+id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mounted_iso_id"), mountedIsoId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rescue_mode"), rescueMode)...)
+}
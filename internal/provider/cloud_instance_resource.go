@@ -3,14 +3,20 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/uthoplatforms/utho-go/utho"
@@ -18,9 +24,11 @@ import (
 
 // implement resource interfaces.
 var (
-	_ resource.Resource                = &CloudInstanceResource{}
-	_ resource.ResourceWithConfigure   = &CloudInstanceResource{}
-	_ resource.ResourceWithImportState = &CloudInstanceResource{}
+	_ resource.Resource                     = &CloudInstanceResource{}
+	_ resource.ResourceWithConfigure        = &CloudInstanceResource{}
+	_ resource.ResourceWithImportState      = &CloudInstanceResource{}
+	_ resource.ResourceWithModifyPlan       = &CloudInstanceResource{}
+	_ resource.ResourceWithConfigValidators = &CloudInstanceResource{}
 )
 
 // NewCloudInstanceResource is a helper function to simplify the provider implementation.
@@ -34,58 +42,76 @@ type CloudInstanceResource struct {
 }
 
 type CloudInstanceResourceModel struct {
-	Name           types.String `tfsdk:"name"`
-	Dcslug         types.String `tfsdk:"dcslug"`
-	Image          types.String `tfsdk:"image"`
-	Planid         types.String `tfsdk:"planid"`
-	Vpcid          types.String `tfsdk:"vpc_id"`
-	RootPassword   types.String `tfsdk:"root_password"`
-	Firewall       types.String `tfsdk:"firewall"`
-	Enablebackup   types.Bool   `tfsdk:"enablebackup"`
-	Backupid       types.String `tfsdk:"backupid"`
-	Snapshotid     types.String `tfsdk:"snapshotid"`
-	Sshkeys        types.String `tfsdk:"sshkeys"`
-	Billingcycle   types.String `tfsdk:"billingcycle"`
-	EnablePublicip types.String `tfsdk:"enable_publicip"`
-	SubnetRequired types.String `tfsdk:"subnetrequired"`
-	Cpumodel       types.String `tfsdk:"cpumodel"`
-	Auth           types.String `tfsdk:"auth"`
-	Support        types.String `tfsdk:"support"`
-	Management     types.String `tfsdk:"management"`
+	Name                types.String `tfsdk:"name"`
+	Dcslug              types.String `tfsdk:"dcslug"`
+	Image               types.String `tfsdk:"image"`
+	Planid              types.String `tfsdk:"planid"`
+	Vpcid               types.String `tfsdk:"vpc_id"`
+	SubnetId            types.String `tfsdk:"subnet_id"`
+	RootPassword        types.String `tfsdk:"root_password"`
+	PasswordLength      types.Int64  `tfsdk:"password_length"`
+	PasswordSpecial     types.Bool   `tfsdk:"password_special"`
+	PasswordAuth        types.Bool   `tfsdk:"password_auth"`
+	Firewall            types.String `tfsdk:"firewall"`
+	Enablebackup        types.Bool   `tfsdk:"enablebackup"`
+	Backupid            types.String `tfsdk:"backupid"`
+	Snapshotid          types.String `tfsdk:"snapshotid"`
+	Sshkeys             types.String `tfsdk:"sshkeys"`
+	Billingcycle        types.String `tfsdk:"billingcycle"`
+	EnablePublicip      types.String `tfsdk:"enable_publicip"`
+	SubnetRequired      types.String `tfsdk:"subnetrequired"`
+	Cpumodel            types.String `tfsdk:"cpumodel"`
+	Auth                types.String `tfsdk:"auth"`
+	Support             types.String `tfsdk:"support"`
+	Management          types.String `tfsdk:"management"`
+	UserData            types.String `tfsdk:"user_data"`
+	UserDataHash        types.String `tfsdk:"user_data_hash"`
+	Ipv6Enabled         types.Bool   `tfsdk:"ipv6_enabled"`
+	AdditionalPublicIps types.Int64  `tfsdk:"additional_public_ips"`
+	AdditionalVpcIds    types.Set    `tfsdk:"additional_vpc_ids"`
+	Tags                types.Map    `tfsdk:"tags"`
+	MountedIsoId        types.String `tfsdk:"mounted_iso_id"`
+	RescueMode          types.Bool   `tfsdk:"rescue_mode"`
 	////////////////////////
-	ID                types.String  `tfsdk:"id"`
-	IP                types.String  `tfsdk:"ip"`
-	CPU               types.String  `tfsdk:"cpu"`
-	RAM               types.String  `tfsdk:"ram"`
-	ManagedOs         types.String  `tfsdk:"managed_os"`
-	ManagedFull       types.String  `tfsdk:"managed_full"`
-	ManagedOnetime    types.String  `tfsdk:"managed_onetime"`
-	PlanDisksize      types.Int64   `tfsdk:"plan_disksize"`
-	Disksize          types.Int64   `tfsdk:"disksize"`
-	Ha                types.String  `tfsdk:"ha"`
-	Status            types.String  `tfsdk:"status"`
-	Iso               types.String  `tfsdk:"iso"`
-	Cost              types.Float64 `tfsdk:"cost"`
-	Vmcost            types.Float64 `tfsdk:"vmcost"`
-	Imagecost         types.Int64   `tfsdk:"imagecost"`
-	Backupcost        types.Int64   `tfsdk:"backupcost"`
-	Hourlycost        types.Float64 `tfsdk:"hourlycost"`
-	Cloudhourlycost   types.Float64 `tfsdk:"cloudhourlycost"`
-	Imagehourlycost   types.Int64   `tfsdk:"imagehourlycost"`
-	Backuphourlycost  types.Int64   `tfsdk:"backuphourlycost"`
-	Creditrequired    types.Float64 `tfsdk:"creditrequired"`
-	Creditreserved    types.Int64   `tfsdk:"creditreserved"`
-	Nextinvoiceamount types.Float64 `tfsdk:"nextinvoiceamount"`
-	Nextinvoicehours  types.String  `tfsdk:"nextinvoicehours"`
-	Consolepassword   types.String  `tfsdk:"consolepassword"`
-	Powerstatus       types.String  `tfsdk:"powerstatus"`
-	CreatedAt         types.String  `tfsdk:"created_at"`
-	UpdatedAt         types.String  `tfsdk:"updated_at"`
-	Nextduedate       types.String  `tfsdk:"nextduedate"`
-	Bandwidth         types.String  `tfsdk:"bandwidth"`
-	BandwidthUsed     types.Int64   `tfsdk:"bandwidth_used"`
-	BandwidthFree     types.Int64   `tfsdk:"bandwidth_free"`
-	GpuAvailable      types.String  `tfsdk:"gpu_available"`
+	ID                   types.String  `tfsdk:"id"`
+	IP                   types.String  `tfsdk:"ip"`
+	CPU                  types.String  `tfsdk:"cpu"`
+	RAM                  types.String  `tfsdk:"ram"`
+	ManagedOs            types.String  `tfsdk:"managed_os"`
+	ManagedFull          types.String  `tfsdk:"managed_full"`
+	ManagedOnetime       types.String  `tfsdk:"managed_onetime"`
+	PlanDisksize         types.Int64   `tfsdk:"plan_disksize"`
+	Disksize             types.Int64   `tfsdk:"disksize"`
+	Ha                   types.String  `tfsdk:"ha"`
+	Status               types.String  `tfsdk:"status"`
+	Iso                  types.String  `tfsdk:"iso"`
+	Cost                 types.Float64 `tfsdk:"cost"`
+	Vmcost               types.Float64 `tfsdk:"vmcost"`
+	Imagecost            types.Int64   `tfsdk:"imagecost"`
+	Backupcost           types.Int64   `tfsdk:"backupcost"`
+	Hourlycost           types.Float64 `tfsdk:"hourlycost"`
+	Cloudhourlycost      types.Float64 `tfsdk:"cloudhourlycost"`
+	Imagehourlycost      types.Int64   `tfsdk:"imagehourlycost"`
+	Backuphourlycost     types.Int64   `tfsdk:"backuphourlycost"`
+	Creditrequired       types.Float64 `tfsdk:"creditrequired"`
+	Creditreserved       types.Int64   `tfsdk:"creditreserved"`
+	Nextinvoiceamount    types.Float64 `tfsdk:"nextinvoiceamount"`
+	Nextinvoicehours     types.String  `tfsdk:"nextinvoicehours"`
+	EstimatedMonthlyCost types.Float64 `tfsdk:"estimated_monthly_cost"`
+	DeletionProtection   types.Bool    `tfsdk:"deletion_protection"`
+	RebuildOnImageChange types.Bool    `tfsdk:"rebuild_on_image_change"`
+	Consolepassword      types.String  `tfsdk:"consolepassword"`
+	Powerstatus          types.String  `tfsdk:"powerstatus"`
+	CreatedAt            types.String  `tfsdk:"created_at"`
+	UpdatedAt            types.String  `tfsdk:"updated_at"`
+	Nextduedate          types.String  `tfsdk:"nextduedate"`
+	Bandwidth            types.String  `tfsdk:"bandwidth"`
+	BandwidthUsed        types.Int64   `tfsdk:"bandwidth_used"`
+	BandwidthFree        types.Int64   `tfsdk:"bandwidth_free"`
+	GpuAvailable         types.String  `tfsdk:"gpu_available"`
+	Ipv4Private          types.String  `tfsdk:"ipv4_private"`
+	Ipv6Address          types.String  `tfsdk:"ipv6_address"`
+	TagsAll              types.Map     `tfsdk:"tags_all"`
 	/////////////////////////
 	Dclocation     types.Object `tfsdk:"dclocation"`
 	PublicNetwork  types.List   `tfsdk:"public_network"`
@@ -167,58 +193,99 @@ func (d *CloudInstanceResource) Configure(_ context.Context, req resource.Config
 // Schema defines the schema for the resource.
 func (s *CloudInstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{Attributes: map[string]schema.Attribute{
-		"id":              schema.StringAttribute{Computed: true, Description: "Cloud id"},
-		"name":            schema.StringAttribute{Required: true, Description: "Give a name to your cloud server eg: myweb1.server.com", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-		"dcslug":          schema.StringAttribute{Required: true, MarkdownDescription: "Provide Zone dcslug eg: innoida. You can find a list of available dcslug on [Utho API documentation](https://utho.com/api-docs/#api-Cloud-Servers-AVAILABLEDCZONES).", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-		"image":           schema.StringAttribute{Required: true, Description: "Image name eg: centos-7.4-x86_64", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-		"root_password":   schema.StringAttribute{Required: true, Description: "Root Password", Sensitive: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-		"planid":          schema.StringAttribute{Optional: true, MarkdownDescription: "The unique ID that identifies the type of Instance plane. You can find a list of available IDs on [Utho API documentation](https://utho.com/api-docs/#api-Cloud-Servers-GETPLANS).", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-		"vpc_id":          schema.StringAttribute{Optional: true, MarkdownDescription: "The unique ID that identifies the VPC. You can list all VPCs id on [Utho API documentation](https://utho.com/api-docs/#api-VPC-VPCList).", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-		"firewall":        schema.StringAttribute{Optional: true, Description: "Firewall Id", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-		"enablebackup":    schema.BoolAttribute{Optional: true, Description: "Please pass value on to enable weekly backups*", PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()}},
-		"billingcycle":    schema.StringAttribute{Optional: true, Description: "If you required billing cycle other then hourly billing you can pass value as eg: monthly, 3month, 6month, 12month. by default its selected as hourly", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-		"backupid":        schema.StringAttribute{Optional: true, Description: "Provide a backupid if you have a backup in same datacenter location.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-		"snapshotid":      schema.StringAttribute{Optional: true, Description: "Provide a snapshot id if you have a snapshot in same datacenter location.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-		"sshkeys":         schema.StringAttribute{Optional: true, Description: "Provide SSH Key ids or pass multiple SSH Key ids with commans (eg: 432,331).", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		"id":     schema.StringAttribute{Computed: true, Description: "Cloud id"},
+		"name":   schema.StringAttribute{Required: true, Description: "Give a name to your cloud server eg: myweb1.server.com", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		"dcslug": schema.StringAttribute{Required: true, MarkdownDescription: "Provide Zone dcslug eg: innoida. You can find a list of available dcslug on [Utho API documentation](https://utho.com/api-docs/#api-Cloud-Servers-AVAILABLEDCZONES).", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		"image": schema.StringAttribute{Required: true, Description: "Image name eg: centos-7.4-x86_64. Changing the image replaces the instance unless rebuild_on_image_change is set",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIf(requiresReplaceWithoutRebuild,
+				"Replaces the instance unless it is rebuilt", "Replaces the instance unless it is rebuilt")},
+		},
+		"deletion_protection":     deletionProtectionAttribute("cloud instance"),
+		"tags":                    tagsAttribute(),
+		"tags_all":                tagsAllAttribute(),
+		"rebuild_on_image_change": schema.BoolAttribute{Optional: true, Description: "Rebuild the instance in place when the image changes, keeping its id, ips and firewall. The root password and sshkeys can change with the image. Defaults to false"},
+		"root_password": schema.StringAttribute{Optional: true, Computed: true, Sensitive: true,
+			Description: "Root Password. A password is generated when the instance is created without one. Changing the configured password replaces the instance",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIf(requiresReplaceRootPasswordChange,
+				"Replaces the instance when the configured root password changes", "Replaces the instance when the configured root password changes")},
+		},
+		"password_length": schema.Int64Attribute{Optional: true, Description: fmt.Sprintf("Length of the generated root password, between %d and %d. Defaults to %d", minPasswordLength, maxPasswordLength, defaultPasswordLength),
+			Validators: []validator.Int64{int64validator.Between(minPasswordLength, maxPasswordLength)},
+		},
+		"password_special": schema.BoolAttribute{Optional: true, Description: "Use special characters in the generated root password, otherwise only letters and digits. Defaults to true"},
+		"password_auth": schema.BoolAttribute{Optional: true, Description: "Set to false to deploy the instance without a root password, sshkeys are then required. Defaults to true",
+			PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+		},
+		"subnet_id": schema.StringAttribute{Optional: true, MarkdownDescription: "Id of a `utho_vpc_subnet` of vpc_id to place the instance in", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			Validators: []validator.String{stringvalidator.AlsoRequires(path.MatchRoot("vpc_id"))},
+		},
+		"planid":       schema.StringAttribute{Optional: true, MarkdownDescription: "The unique ID that identifies the type of Instance plane. You can find a list of available IDs on [Utho API documentation](https://utho.com/api-docs/#api-Cloud-Servers-GETPLANS).", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		"vpc_id":       schema.StringAttribute{Optional: true, MarkdownDescription: "The unique ID that identifies the VPC. You can list all VPCs id on [Utho API documentation](https://utho.com/api-docs/#api-VPC-VPCList).", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		"firewall":     schema.StringAttribute{Optional: true, Description: "Firewall Id", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		"enablebackup": schema.BoolAttribute{Optional: true, Description: "Please pass value on to enable weekly backups*", PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()}},
+		"billingcycle": schema.StringAttribute{Optional: true, Description: "If you required billing cycle other then hourly billing you can pass value as eg: monthly, 3month, 6month, 12month. by default its selected as hourly", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		"backupid":     schema.StringAttribute{Optional: true, Description: "Provide a backupid if you have a backup in same datacenter location.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		"snapshotid":   schema.StringAttribute{Optional: true, Description: "Provide a snapshot id if you have a snapshot in same datacenter location.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		"sshkeys": schema.StringAttribute{Optional: true, Description: "Provide SSH Key ids or pass multiple SSH Key ids with commans (eg: 432,331).",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIf(requiresReplaceWithoutRebuild,
+				"Replaces the instance unless it is rebuilt", "Replaces the instance unless it is rebuilt")},
+		},
 		"enable_publicip": schema.StringAttribute{Optional: true, Description: "Enable Public IP", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
 		"subnetrequired":  schema.StringAttribute{Optional: true, Description: "Subnet Required", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
 		"cpumodel":        schema.StringAttribute{Optional: true, Description: "CPU Model", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
 		"auth":            schema.StringAttribute{Optional: true, Description: "Authentication", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
 		"support":         schema.StringAttribute{Optional: true, Description: "Support", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
 		"management":      schema.StringAttribute{Optional: true, Description: "Management", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		"user_data": schema.StringAttribute{Optional: true, Sensitive: true,
+			MarkdownDescription: "Cloud-init user data applied when the instance is created, raw or base64 encoded, at most 64 KiB. The `utho_cloudinit_config` data source can assemble multi-part cloud-config documents. Changing the script replaces the instance",
+			Validators:          []validator.String{userDataValidator{}},
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIf(requiresReplaceUserDataChange,
+				"Replaces the instance when the user data script changes", "Replaces the instance when the user data script changes")},
+		},
+		"user_data_hash": schema.StringAttribute{Computed: true, Description: "SHA-256 of the user data script, empty without user data"},
+		"mounted_iso_id": schema.StringAttribute{Optional: true, MarkdownDescription: "Id of a `utho_custom_iso` to mount on the instance, changed in place. Removing it unmounts the iso"},
+		"rescue_mode":    schema.BoolAttribute{Optional: true, Description: "Boot the instance into the rescue system to repair its disk, changed in place. Defaults to false"},
+		"ipv6_enabled":   schema.BoolAttribute{Optional: true, Description: "Assign an ipv6 address to the instance, changed in place. When not set the ipv6 address is not managed"},
+		"additional_public_ips": schema.Int64Attribute{Optional: true, Description: "Number of public ipv4 addresses besides the primary ip, changed in place. When not set the additional ips are not managed",
+			Validators: []validator.Int64{int64validator.AtLeast(0)},
+		},
+		"additional_vpc_ids": schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "VPCs to attach the instance to besides vpc_id, attached and detached in place. When not set the attached vpcs are not managed"},
 
-		"ip":                schema.StringAttribute{Computed: true, Description: "Ip"},
-		"cpu":               schema.StringAttribute{Computed: true, Description: "Cpu"},
-		"ram":               schema.StringAttribute{Computed: true, Description: "Ram"},
-		"managed_os":        schema.StringAttribute{Computed: true, Description: "Managed Os"},
-		"managed_full":      schema.StringAttribute{Computed: true, Description: "Managed Full"},
-		"managed_onetime":   schema.StringAttribute{Computed: true, Description: "Managed Onetime"},
-		"plan_disksize":     schema.Int64Attribute{Computed: true, Description: "Plan Disksize"},
-		"disksize":          schema.Int64Attribute{Computed: true, Description: "Disksize"},
-		"ha":                schema.StringAttribute{Computed: true, Description: "Ha"},
-		"status":            schema.StringAttribute{Computed: true, Description: "Status"},
-		"iso":               schema.StringAttribute{Computed: true, Description: "Iso"},
-		"cost":              schema.Float64Attribute{Computed: true, Description: "Cost"},
-		"vmcost":            schema.Float64Attribute{Computed: true, Description: "Vmcost"},
-		"imagecost":         schema.Int64Attribute{Computed: true, Description: "Imagecost"},
-		"backupcost":        schema.Int64Attribute{Computed: true, Description: "Backupcost"},
-		"hourlycost":        schema.Float64Attribute{Computed: true, Description: "Hourlycost"},
-		"cloudhourlycost":   schema.Float64Attribute{Computed: true, Description: "Cloudhourlycost"},
-		"imagehourlycost":   schema.Int64Attribute{Computed: true, Description: "Imagehourlycost"},
-		"backuphourlycost":  schema.Int64Attribute{Computed: true, Description: "Backuphourlycost"},
-		"creditrequired":    schema.Float64Attribute{Computed: true, Description: "Creditrequired"},
-		"creditreserved":    schema.Int64Attribute{Computed: true, Description: "Creditreserved"},
-		"nextinvoiceamount": schema.Float64Attribute{Computed: true, Description: "Nextinvoiceamount"},
-		"nextinvoicehours":  schema.StringAttribute{Computed: true, Description: "Nextinvoicehours"},
-		"consolepassword":   schema.StringAttribute{Computed: true, Description: "Consolepassword"},
-		"powerstatus":       schema.StringAttribute{Computed: true, Description: "Powerstatus"},
-		"created_at":        schema.StringAttribute{Computed: true, Description: "Created At"},
-		"updated_at":        schema.StringAttribute{Computed: true, Description: "Updated At"},
-		"nextduedate":       schema.StringAttribute{Computed: true, Description: "Nextduedate"},
-		"bandwidth":         schema.StringAttribute{Computed: true, Description: "Bandwidth"},
-		"bandwidth_used":    schema.Int64Attribute{Computed: true, Description: "Bandwidth Used"},
-		"bandwidth_free":    schema.Int64Attribute{Computed: true, Description: "Bandwidth Free"},
-		"gpu_available":     schema.StringAttribute{Computed: true, Description: "Gpu Available"},
+		"ip":                     schema.StringAttribute{Computed: true, Description: "Ip"},
+		"cpu":                    schema.StringAttribute{Computed: true, Description: "Cpu"},
+		"ram":                    schema.StringAttribute{Computed: true, Description: "Ram"},
+		"managed_os":             schema.StringAttribute{Computed: true, Description: "Managed Os"},
+		"managed_full":           schema.StringAttribute{Computed: true, Description: "Managed Full"},
+		"managed_onetime":        schema.StringAttribute{Computed: true, Description: "Managed Onetime"},
+		"plan_disksize":          schema.Int64Attribute{Computed: true, Description: "Plan Disksize"},
+		"disksize":               schema.Int64Attribute{Computed: true, Description: "Disksize"},
+		"ha":                     schema.StringAttribute{Computed: true, Description: "Ha"},
+		"status":                 schema.StringAttribute{Computed: true, Description: "Status"},
+		"iso":                    schema.StringAttribute{Computed: true, Description: "Iso"},
+		"cost":                   schema.Float64Attribute{Computed: true, Description: "Cost"},
+		"vmcost":                 schema.Float64Attribute{Computed: true, Description: "Vmcost"},
+		"imagecost":              schema.Int64Attribute{Computed: true, Description: "Imagecost"},
+		"backupcost":             schema.Int64Attribute{Computed: true, Description: "Backupcost"},
+		"hourlycost":             schema.Float64Attribute{Computed: true, Description: "Hourlycost"},
+		"cloudhourlycost":        schema.Float64Attribute{Computed: true, Description: "Cloudhourlycost"},
+		"imagehourlycost":        schema.Int64Attribute{Computed: true, Description: "Imagehourlycost"},
+		"backuphourlycost":       schema.Int64Attribute{Computed: true, Description: "Backuphourlycost"},
+		"creditrequired":         schema.Float64Attribute{Computed: true, Description: "Creditrequired"},
+		"creditreserved":         schema.Int64Attribute{Computed: true, Description: "Creditreserved"},
+		"nextinvoiceamount":      schema.Float64Attribute{Computed: true, Description: "Nextinvoiceamount"},
+		"nextinvoicehours":       schema.StringAttribute{Computed: true, Description: "Nextinvoicehours"},
+		"estimated_monthly_cost": schema.Float64Attribute{Computed: true, Description: "Monthly cost estimated from the plan pricing when the instance was planned, including backups", PlanModifiers: []planmodifier.Float64{float64planmodifier.UseStateForUnknown()}},
+		"consolepassword":        schema.StringAttribute{Computed: true, Description: "Consolepassword"},
+		"powerstatus":            schema.StringAttribute{Computed: true, Description: "Powerstatus"},
+		"created_at":             schema.StringAttribute{Computed: true, Description: "Created At"},
+		"updated_at":             schema.StringAttribute{Computed: true, Description: "Updated At"},
+		"nextduedate":            schema.StringAttribute{Computed: true, Description: "Nextduedate"},
+		"bandwidth":              schema.StringAttribute{Computed: true, Description: "Bandwidth"},
+		"bandwidth_used":         schema.Int64Attribute{Computed: true, Description: "Bandwidth Used"},
+		"bandwidth_free":         schema.Int64Attribute{Computed: true, Description: "Bandwidth Free"},
+		"gpu_available":          schema.StringAttribute{Computed: true, Description: "Gpu Available"},
+		"ipv4_private":           schema.StringAttribute{Computed: true, Description: "Primary private ipv4 address, empty without private network"},
+		"ipv6_address":           schema.StringAttribute{Computed: true, Description: "Primary ipv6 address, empty without ipv6"},
 		"dclocation": schema.SingleNestedAttribute{
 			Computed:    true,
 			Description: "dclocation",
@@ -303,6 +370,31 @@ func (s *CloudInstanceResource) Schema(_ context.Context, _ resource.SchemaReque
 	}
 }
 
+// ModifyPlan validates the planned values against the utho api.
+func (s *CloudInstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkDeletionProtectionPlan(ctx, req, resp, "cloud instance", "id")
+	validateDcslugPlan(ctx, s.client, req, resp)
+	validatePlanidPlan(ctx, s.client, req, resp)
+	validateImagePlan(ctx, s.client, req, resp, "image")
+	estimateCloudInstanceCost(ctx, s.client, req, resp)
+	planRootPassword(ctx, req, resp)
+	planTagsAll(ctx, s.client, req, resp)
+
+	if !req.Plan.Raw.IsNull() {
+		var userData types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("user_data"), &userData)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("user_data_hash"), userDataHash(userData))...)
+	}
+}
+
+// ConfigValidators checks the password auth and vpc settings.
+func (s *CloudInstanceResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		cloudInstancePasswordAuthValidator{},
+		cloudInstanceVpcValidator{},
+	}
+}
+
 // Import using cloud instance as the attribute
 func (s *CloudInstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
@@ -324,11 +416,24 @@ func (s *CloudInstanceResource) Create(ctx context.Context, req resource.CreateR
 		false: "false",
 		true:  "true",
 	}
+	// generate the root password when it is not configured
+	if plan.RootPassword.IsUnknown() {
+		password, err := generateRootPassword(plan.PasswordLength, plan.PasswordSpecial)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error generating root password",
+				"Could not generate root password: "+err.Error(),
+			)
+			return
+		}
+		plan.RootPassword = types.StringValue(password)
+	}
+
 	hostName := []utho.CloudHostname{}
 	hostName = append(hostName, utho.CloudHostname{Hostname: plan.Name.ValueString()})
 
 	// Generate API request body from plan
-	cloudinstanceRequest := utho.CreateCloudInstanceParams{
+	cloudinstanceRequest := createCloudInstanceParams{CreateCloudInstanceParams: utho.CreateCloudInstanceParams{
 		Dcslug:         plan.Dcslug.ValueString(),
 		Image:          plan.Image.ValueString(),
 		Planid:         plan.Planid.ValueString(),
@@ -347,11 +452,22 @@ func (s *CloudInstanceResource) Create(ctx context.Context, req resource.CreateR
 		Auth:           plan.Auth.ValueString(),
 		Support:        plan.Support.ValueString(),
 		Management:     plan.Management.ValueString(),
+	}}
+	cloudinstanceRequest.Subnet = plan.SubnetId.ValueString()
+	if !plan.UserData.IsNull() {
+		cloudinstanceRequest.Cloudinit = encodeUserData(plan.UserData.ValueString())
+	}
+	var tags map[string]string
+	diags = plan.TagsAll.ElementsAs(ctx, &tags, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	cloudinstanceRequest.Tags = encodeTags(tags)
 
 	tflog.Debug(ctx, "send create cloud instance request")
 
-	cloudinstance, err := s.client.CloudInstances().Create(cloudinstanceRequest)
+	cloudinstance, err := createCloudInstance(s.client, cloudinstanceRequest)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -361,7 +477,7 @@ func (s *CloudInstanceResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	getCloudInstance, err := s.client.CloudInstances().Read(cloudinstance.ID)
+	getCloudInstance, err := readCloudInstance(s.client, cloudinstance.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading utho cloud instance",
@@ -370,6 +486,33 @@ func (s *CloudInstanceResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	// add the configured ipv6, public ips and vpcs, iso and rescue mode once the instance is running
+	changes := cloudInstanceNetworkChanges(getCloudInstance, plan.Ipv6Enabled, plan.AdditionalPublicIps, plan.AdditionalVpcIds)
+	recovery := plan.MountedIsoId.ValueString() != "" || plan.RescueMode.ValueBool()
+	if len(changes) > 0 || recovery {
+		_, err = s.waitForRunning(ctx, cloudinstance.ID)
+		if err == nil {
+			getCloudInstance, err = s.applyNetworkChanges(ctx, cloudinstance.ID, changes)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating utho cloud instance networks",
+				"Could not update the networks of utho cloud instance "+cloudinstance.ID+": "+err.Error(),
+			)
+			return
+		}
+	}
+	if recovery {
+		err = applyRecovery(ctx, s.client, cloudinstance.ID, plan.MountedIsoId, types.StringNull(), plan.RescueMode, types.BoolNull())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating utho cloud instance recovery",
+				"Could not change the iso or rescue mode of utho cloud instance "+cloudinstance.ID+": "+err.Error(),
+			)
+			return
+		}
+	}
+
 	// map response value to more readable value
 	enableBackupMap := map[string]bool{
 		"0": false,
@@ -377,7 +520,6 @@ func (s *CloudInstanceResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	plan.RootPassword = types.StringValue(cloudinstance.Password)
 	plan.ID = types.StringValue(cloudinstance.ID)
 	plan.IP = types.StringValue(cloudinstance.Ipv4)
 	plan.CPU = types.StringValue(getCloudInstance.CPU)
@@ -402,6 +544,9 @@ func (s *CloudInstanceResource) Create(ctx context.Context, req resource.CreateR
 	plan.Creditreserved = types.Int64Value(int64(getCloudInstance.Creditreserved))
 	plan.Nextinvoiceamount = types.Float64Value(getCloudInstance.Nextinvoiceamount)
 	plan.Nextinvoicehours = types.StringValue(getCloudInstance.Nextinvoicehours)
+	if plan.EstimatedMonthlyCost.IsUnknown() {
+		plan.EstimatedMonthlyCost = types.Float64Null()
+	}
 	plan.Consolepassword = types.StringValue(getCloudInstance.Consolepassword)
 	plan.Powerstatus = types.StringValue(getCloudInstance.Powerstatus)
 	plan.CreatedAt = types.StringValue(getCloudInstance.CreatedAt)
@@ -434,69 +579,8 @@ func (s *CloudInstanceResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	// PrivateNetwork
-	var privateNetworkObjType = types.ObjectType{AttrTypes: map[string]attr.Type{
-		"noip":       types.Int64Type,
-		"ip_address": types.StringType,
-		"vpc_name":   types.StringType,
-		"network":    types.StringType,
-		"vpc_id":     types.StringType,
-		"netmask":    types.StringType,
-		"gateway":    types.StringType,
-		"type":       types.StringType,
-		"primary":    types.StringType,
-	}}
-	privateNetworkModel := make([]PrivateNetworkResourceModel, len(getCloudInstance.Networks.Private.V4))
-	for i, v := range getCloudInstance.Networks.Private.V4 {
-		privateNetworkModel[i] = PrivateNetworkResourceModel{
-			Noip:      types.Int64Value(int64(v.Noip)),
-			IPAddress: types.StringValue(v.IPAddress),
-			VpcName:   types.StringValue(v.VpcName),
-			Network:   types.StringValue(v.Network),
-			VpcID:     types.StringValue(v.VpcID),
-			Netmask:   types.StringValue(v.Netmask),
-			Gateway:   types.StringValue(v.Gateway),
-			Type:      types.StringValue(v.Type),
-			Primary:   types.StringValue(v.Primary),
-		}
-	}
-	privateNetworkList, diags := types.ListValueFrom(ctx, privateNetworkObjType, privateNetworkModel)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	diags = resp.State.SetAttribute(ctx, path.Root("private_network"), privateNetworkList)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	// PublicNetwork
-	var publicNetworkObjType = types.ObjectType{AttrTypes: map[string]attr.Type{
-		"ip_address": types.StringType,
-		"netmask":    types.StringType,
-		"gateway":    types.StringType,
-		"type":       types.StringType,
-		"nat":        types.BoolType,
-		"primary":    types.StringType,
-	}}
-	PublicNetworkModel := make([]PublicNetworkResourceModel, len(getCloudInstance.Networks.Public.V4))
-	for i, v := range getCloudInstance.Networks.Public.V4 {
-		PublicNetworkModel[i] = PublicNetworkResourceModel{
-			IPAddress: types.StringValue(v.IPAddress),
-			Netmask:   types.StringValue(v.Netmask),
-			Gateway:   types.StringValue(v.Gateway),
-			Type:      types.StringValue(v.Type),
-			Nat:       types.BoolValue(v.Nat),
-			Primary:   types.StringValue(v.Primary),
-		}
-	}
-	publicNetworkList, diags := types.ListValueFrom(ctx, publicNetworkObjType, PublicNetworkModel)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	diags = resp.State.SetAttribute(ctx, path.Root("public_network"), publicNetworkList)
+	// PrivateNetwork and PublicNetwork
+	diags = setCloudInstanceNetworkState(ctx, &resp.State, getCloudInstance)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -606,7 +690,7 @@ func (s *CloudInstanceResource) Read(ctx context.Context, req resource.ReadReque
 
 	tflog.Debug(ctx, "send get cloud instance request")
 	// Get refreshed cloud instance value from utho
-	cloudinstance, err := s.client.CloudInstances().Read(state.ID.ValueString())
+	cloudinstance, err := readCloudInstance(s.client, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading utho cloud instance",
@@ -709,6 +793,36 @@ func (s *CloudInstanceResource) Read(ctx context.Context, req resource.ReadReque
 	if !state.Management.IsNull() {
 		state.Management = types.StringValue(state.Management.ValueString())
 	}
+	if !state.Ipv6Enabled.IsNull() {
+		state.Ipv6Enabled = types.BoolValue(len(cloudinstance.Networks.Public.V6) > 0)
+	}
+	if !state.AdditionalPublicIps.IsNull() {
+		state.AdditionalPublicIps = types.Int64Value(int64(len(cloudInstanceAdditionalPublicIPs(cloudinstance))))
+	}
+	tagsAll := decodeTags(cloudinstance.Tags)
+	state.TagsAll, diags = types.MapValueFrom(ctx, types.StringType, tagsAll)
+	resp.Diagnostics.Append(diags...)
+	state.Tags, diags = types.MapValueFrom(ctx, types.StringType, resourceTags(tagsAll, defaultTagsFor(s.client), state.Tags))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !state.MountedIsoId.IsNull() {
+		state.MountedIsoId = types.StringNull()
+		if cloudinstance.Iso != "" {
+			state.MountedIsoId = types.StringValue(cloudinstance.Iso)
+		}
+	}
+	if !state.RescueMode.IsNull() {
+		state.RescueMode = types.BoolValue(cloudinstance.Rescue == 1)
+	}
+	if !state.AdditionalVpcIds.IsNull() {
+		state.AdditionalVpcIds, diags = types.SetValueFrom(ctx, types.StringType, cloudInstanceAdditionalVpcIDs(cloudinstance))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -731,67 +845,8 @@ func (s *CloudInstanceResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	var privateNetworkObjType = types.ObjectType{AttrTypes: map[string]attr.Type{
-		"noip":       types.Int64Type,
-		"ip_address": types.StringType,
-		"vpc_name":   types.StringType,
-		"network":    types.StringType,
-		"vpc_id":     types.StringType,
-		"netmask":    types.StringType,
-		"gateway":    types.StringType,
-		"type":       types.StringType,
-		"primary":    types.StringType,
-	}}
-	privateNetworkModel := make([]PrivateNetworkResourceModel, len(cloudinstance.Networks.Private.V4))
-	for i, v := range cloudinstance.Networks.Private.V4 {
-		privateNetworkModel[i] = PrivateNetworkResourceModel{
-			Noip:      types.Int64Value(int64(v.Noip)),
-			IPAddress: types.StringValue(v.IPAddress),
-			VpcName:   types.StringValue(v.VpcName),
-			Network:   types.StringValue(v.Network),
-			VpcID:     types.StringValue(v.VpcID),
-			Netmask:   types.StringValue(v.Netmask),
-			Gateway:   types.StringValue(v.Gateway),
-			Type:      types.StringValue(v.Type),
-			Primary:   types.StringValue(v.Primary),
-		}
-	}
-	privateNetworkList, diags := types.ListValueFrom(ctx, privateNetworkObjType, privateNetworkModel)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	diags = resp.State.SetAttribute(ctx, path.Root("private_network"), privateNetworkList)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	var publicNetworkObjType = types.ObjectType{AttrTypes: map[string]attr.Type{
-		"ip_address": types.StringType,
-		"netmask":    types.StringType,
-		"gateway":    types.StringType,
-		"type":       types.StringType,
-		"nat":        types.BoolType,
-		"primary":    types.StringType,
-	}}
-	PublicNetworkModel := make([]PublicNetworkResourceModel, len(cloudinstance.Networks.Public.V4))
-	for i, v := range cloudinstance.Networks.Public.V4 {
-		PublicNetworkModel[i] = PublicNetworkResourceModel{
-			IPAddress: types.StringValue(v.IPAddress),
-			Netmask:   types.StringValue(v.Netmask),
-			Gateway:   types.StringValue(v.Gateway),
-			Type:      types.StringValue(v.Type),
-			Nat:       types.BoolValue(v.Nat),
-			Primary:   types.StringValue(v.Primary),
-		}
-	}
-	publicNetworkList, diags := types.ListValueFrom(ctx, publicNetworkObjType, PublicNetworkModel)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	diags = resp.State.SetAttribute(ctx, path.Root("public_network"), publicNetworkList)
+	// PrivateNetwork and PublicNetwork
+	diags = setCloudInstanceNetworkState(ctx, &resp.State, cloudinstance)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -885,7 +940,184 @@ func (s *CloudInstanceResource) Read(ctx context.Context, req resource.ReadReque
 }
 
 func (s *CloudInstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// updating resource is not supported
+	// a changed image is rebuilt in place and changed tags, networks, iso and rescue mode are applied, other changes replace the instance or are only adopted in the state
+	resp.State.Raw = req.State.Raw.Copy()
+
+	var image, currentImage types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("image"), &image)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("image"), &currentImage)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !image.Equal(currentImage) {
+		s.rebuild(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var tagsAll, currentTagsAll types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags_all"), &tagsAll)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("tags_all"), &currentTagsAll)...)
+	if !resp.Diagnostics.HasError() && !tagsAll.IsUnknown() && !tagsAll.Equal(currentTagsAll) {
+		s.updateTags(ctx, req, resp, tagsAll)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	networksChanged, diags := cloudInstanceNetworksChanged(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if networksChanged {
+		s.updateNetworks(ctx, req, resp)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	s.updateRecovery(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	adoptPlannedAttributes(ctx, req, resp, cloudInstanceAdoptedAttributes...)
+}
+
+// updateTags replaces the tags of the instance with the planned tags_all.
+func (s *CloudInstanceResource) updateTags(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse, tagsAll types.Map) {
+	var id types.String
+	var tags map[string]string
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(tagsAll.ElementsAs(ctx, &tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "send update cloud instance tags request")
+	_, err := updateCloudInstanceTags(s.client, id.ValueString(), encodeTags(tags))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating utho cloud instance tags",
+			"Could not update the tags of utho cloud instance "+id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+}
+
+// cloudInstanceRebuildPollInterval is how often the instance is read while waiting for a rebuild.
+var cloudInstanceRebuildPollInterval = 15 * time.Second
+
+// cloudInstanceRebuildTimeout is how long a rebuild may take.
+const cloudInstanceRebuildTimeout = 30 * time.Minute
+
+// rebuild reinstalls the instance with the planned image, root password and ssh keys.
+// The instance keeps its id, ips and firewall.
+func (s *CloudInstanceResource) rebuild(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var id, image, rootPassword, sshkeys types.String
+	var passwordLength types.Int64
+	var passwordSpecial types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("image"), &image)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("root_password"), &rootPassword)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("sshkeys"), &sshkeys)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("password_length"), &passwordLength)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("password_special"), &passwordSpecial)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if rootPassword.IsUnknown() {
+		password, err := generateRootPassword(passwordLength, passwordSpecial)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error generating root password",
+				"Could not generate root password: "+err.Error(),
+			)
+			return
+		}
+		rootPassword = types.StringValue(password)
+	}
+
+	tflog.Debug(ctx, "send rebuild cloud instance request")
+	_, err := rebuildCloudInstance(s.client, id.ValueString(), rebuildCloudInstanceParams{
+		RebuildCloudInstanceParams: utho.RebuildCloudInstanceParams{
+			Image:   image.ValueString(),
+			Confirm: "I am aware this action will delete data permanently and build a fresh server",
+		},
+		RootPassword: rootPassword.ValueString(),
+		Sshkeys:      sshkeys.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error rebuilding utho cloud instance",
+			"Could not rebuild utho cloud instance "+id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	cloudinstance, err := s.waitForRunning(ctx, id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error rebuilding utho cloud instance",
+			"Cloud instance "+id.ValueString()+" did not return to running after the rebuild: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("image"), image)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("root_password"), rootPassword)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("sshkeys"), sshkeys)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("status"), types.StringValue(cloudinstance.Status))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("powerstatus"), types.StringValue(cloudinstance.Powerstatus))...)
+}
+
+// waitForRunning waits until the instance is active and powered on.
+func (s *CloudInstanceResource) waitForRunning(ctx context.Context, id string) (*utho.CloudInstance, error) {
+	ctx, cancel := context.WithTimeout(ctx, cloudInstanceRebuildTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(cloudInstanceRebuildPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		cloudinstance, err := s.client.CloudInstances().Read(id)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(cloudinstance.Status, "active") && strings.EqualFold(cloudinstance.Powerstatus, "running") {
+			return cloudinstance, nil
+		}
+	}
+}
+
+// requiresReplaceWithoutRebuild replaces the instance unless rebuild_on_image_change is set and the image changes,
+// the instance is then rebuilt with the new image, root password and ssh keys.
+func requiresReplaceWithoutRebuild(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var rebuildOnImageChange types.Bool
+	var image, currentImage types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rebuild_on_image_change"), &rebuildOnImageChange)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("image"), &image)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("image"), &currentImage)...)
+	resp.RequiresReplace = !rebuildOnImageChange.ValueBool() || image.Equal(currentImage)
+}
+
+// cloudInstanceAdoptedAttributes are updated in the state only: provider settings like the password generation
+// and deletion protection, the tags as applied through tags_all, estimates made when planning, and the user data and root password of imported instances.
+var cloudInstanceAdoptedAttributes = []string{
+	"user_data",
+	"user_data_hash",
+	"estimated_monthly_cost",
+	"root_password",
+	"password_length",
+	"password_special",
+	"rebuild_on_image_change",
+	"deletion_protection",
+	"tags",
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -898,6 +1130,10 @@ func (s *CloudInstanceResource) Delete(ctx context.Context, req resource.DeleteR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, "cloud instance", state.ID.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	tflog.Debug(ctx, "send delete cloud instance request")
 	// delete cloud instance
 	deleteCloudInstanceParams := utho.DeleteCloudInstanceParams{Confirm: "I am aware this action will delete data and server permanently"}
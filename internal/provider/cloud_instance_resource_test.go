@@ -1,9 +1,16 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccCloudInstanceResource(t *testing.T) {
@@ -42,6 +49,33 @@ resource "utho_cloud_instance" "example" {
 					resource.TestCheckResourceAttrSet(resourceName, "vmcost"),
 				),
 			},
+			{
+				Config: providerConfig + `
+resource "utho_cloud_instance" "example" {
+	name = "example-name"
+	# country slug
+	dcslug        = "inmumbaizone2"
+	image         = "ubuntu-24.04-x86_64"
+	planid        = "10045"
+	enablebackup  = "false"
+	billingcycle  = "hourly"
+	firewall      = "23432614"
+	vpc_id		  = "4de5f07a-f51c-4323-b39a-ef66130e1bd9"
+	root_password = "2uDsQ1$Ioqa@uFj"
+
+	rebuild_on_image_change = true
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "image", "ubuntu-24.04-x86_64"),
+					resource.TestCheckResourceAttr(resourceName, "powerstatus", "Running"),
+				),
+			},
 			{
 				ResourceName:      resourceName,
 				ImportState:       true,
@@ -57,8 +91,67 @@ resource "utho_cloud_instance" "example" {
 					"vmcost",
 					"disksize",
 					"vpc_id",
+					"estimated_monthly_cost",
+					"user_data_hash",
+					"rebuild_on_image_change",
 				},
 			},
 		},
 	})
 }
+
+func TestRequiresReplaceWithoutRebuild(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewCloudInstanceResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	object := func(image string, rebuild any) tftypes.Value {
+		return testObject(schemaResp.Schema, map[string]tftypes.Value{
+			"image":                   tftypes.NewValue(tftypes.String, image),
+			"rebuild_on_image_change": tftypes.NewValue(tftypes.Bool, rebuild),
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		image           string
+		rebuild         any
+		requiresReplace bool
+	}{
+		"image change":              {image: "ubuntu-24.04-x86_64", requiresReplace: true},
+		"image change with rebuild": {image: "ubuntu-24.04-x86_64", rebuild: true},
+		"rebuild disabled":          {image: "ubuntu-24.04-x86_64", rebuild: false, requiresReplace: true},
+		"same image with rebuild":   {image: "ubuntu-22.04-x86_64", rebuild: true, requiresReplace: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := planmodifier.StringRequest{
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: object(tc.image, tc.rebuild)},
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: object("ubuntu-22.04-x86_64", nil)},
+			}
+			resp := &stringplanmodifier.RequiresReplaceIfFuncResponse{}
+			requiresReplaceWithoutRebuild(ctx, req, resp)
+			if resp.Diagnostics.HasError() || resp.RequiresReplace != tc.requiresReplace {
+				t.Errorf("requires replace = %t, want %t: %v", resp.RequiresReplace, tc.requiresReplace, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCloudInstanceResourceModel(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewCloudInstanceResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	object := testObject(schemaResp.Schema, map[string]tftypes.Value{
+		"rebuild_on_image_change": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	var plan, state CloudInstanceResourceModel
+	if diags := (tfsdk.Plan{Schema: schemaResp.Schema, Raw: object}).Get(ctx, &plan); diags.HasError() {
+		t.Fatalf("Plan.Get: %v", diags)
+	}
+	if diags := (tfsdk.State{Schema: schemaResp.Schema, Raw: object}).Get(ctx, &state); diags.HasError() {
+		t.Fatalf("State.Get: %v", diags)
+	}
+	if !state.RebuildOnImageChange.ValueBool() {
+		t.Error("rebuild_on_image_change not decoded")
+	}
+}
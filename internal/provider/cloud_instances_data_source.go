@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// NewCloudInstancesDataSource is a helper function to simplify the provider implementation.
+func NewCloudInstancesDataSource() datasource.DataSource {
+	return &listDataSource{
+		typeName:    "_cloud_instances",
+		attribute:   "cloud_instances",
+		description: "Cloud instances",
+		itemAttributes: map[string]schema.Attribute{
+			"id":           schema.StringAttribute{Computed: true, Description: "Cloud id"},
+			"name":         schema.StringAttribute{Computed: true, Description: "Hostname"},
+			"dcslug":       schema.StringAttribute{Computed: true, Description: "Zone dcslug"},
+			"image":        schema.StringAttribute{Computed: true, Description: "Image name"},
+			"ip":           schema.StringAttribute{Computed: true, Description: "Ip"},
+			"cpu":          schema.StringAttribute{Computed: true, Description: "Cpu"},
+			"ram":          schema.StringAttribute{Computed: true, Description: "Ram"},
+			"disksize":     schema.Int64Attribute{Computed: true, Description: "Disksize"},
+			"billingcycle": schema.StringAttribute{Computed: true, Description: "Billing cycle"},
+			"status":       schema.StringAttribute{Computed: true, Description: "Status"},
+			"powerstatus":  schema.StringAttribute{Computed: true, Description: "Powerstatus"},
+			"created_at":   schema.StringAttribute{Computed: true, Description: "Created At"},
+		},
+		list: listCloudInstances,
+	}
+}
+
+func listCloudInstances(client utho.Client) ([]map[string]attr.Value, error) {
+	cloudInstances, err := client.CloudInstances().List()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]attr.Value, 0, len(cloudInstances))
+	for _, cloudInstance := range cloudInstances {
+		items = append(items, map[string]attr.Value{
+			"id":           types.StringValue(cloudInstance.ID),
+			"name":         types.StringValue(cloudInstance.Hostname),
+			"dcslug":       types.StringValue(cloudInstance.Dclocation.Dc),
+			"image":        types.StringValue(cloudInstance.Image.Image),
+			"ip":           types.StringValue(cloudInstance.IP),
+			"cpu":          types.StringValue(cloudInstance.CPU),
+			"ram":          types.StringValue(cloudInstance.RAM),
+			"disksize":     types.Int64Value(int64(cloudInstance.Disksize)),
+			"billingcycle": types.StringValue(cloudInstance.Billingcycle),
+			"status":       types.StringValue(cloudInstance.Status),
+			"powerstatus":  types.StringValue(cloudInstance.Powerstatus),
+			"created_at":   types.StringValue(cloudInstance.CreatedAt),
+		})
+	}
+	return items, nil
+}
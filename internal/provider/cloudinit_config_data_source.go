@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &CloudinitConfigDataSource{}
+
+// NewCloudinitConfigDataSource is a helper function to simplify the provider implementation.
+func NewCloudinitConfigDataSource() datasource.DataSource {
+	return &CloudinitConfigDataSource{}
+}
+
+// CloudinitConfigDataSource renders a multi-part cloud-init document for the user_data of a cloud instance.
+// It does not call the utho api.
+type CloudinitConfigDataSource struct{}
+
+type CloudinitConfigDataSourceModel struct {
+	ID           types.String          `tfsdk:"id"`
+	Gzip         types.Bool            `tfsdk:"gzip"`
+	Base64Encode types.Bool            `tfsdk:"base64_encode"`
+	Boundary     types.String          `tfsdk:"boundary"`
+	Parts        []CloudinitConfigPart `tfsdk:"part"`
+	Rendered     types.String          `tfsdk:"rendered"`
+}
+
+type CloudinitConfigPart struct {
+	ContentType types.String `tfsdk:"content_type"`
+	Content     types.String `tfsdk:"content"`
+	Filename    types.String `tfsdk:"filename"`
+	MergeType   types.String `tfsdk:"merge_type"`
+}
+
+const (
+	cloudinitDefaultBoundary    = "MIMEBOUNDARY"
+	cloudinitDefaultContentType = "text/plain"
+)
+
+func (d *CloudinitConfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloudinit_config"
+}
+
+// Schema defines the schema for the data source.
+func (d *CloudinitConfigDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Renders a multi-part cloud-init document to pass as the `user_data` of a `utho_cloud_instance`.",
+		Attributes: map[string]schema.Attribute{
+			"id":            schema.StringAttribute{Computed: true, Description: "SHA-256 of the rendered document"},
+			"gzip":          schema.BoolAttribute{Optional: true, Description: "Compress the document with gzip, requires base64_encode. Defaults to false"},
+			"base64_encode": schema.BoolAttribute{Optional: true, Description: "Base64 encode the document. Defaults to false, or true when gzip is set"},
+			"boundary":      schema.StringAttribute{Optional: true, Description: "Boundary between the parts. Defaults to " + cloudinitDefaultBoundary},
+			"rendered":      schema.StringAttribute{Computed: true, Description: "The rendered multi-part document"},
+		},
+		Blocks: map[string]schema.Block{
+			"part": schema.ListNestedBlock{
+				Description: "A part of the document, parts are rendered in order",
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"content_type": schema.StringAttribute{Optional: true, Description: "MIME type of the part, eg: text/cloud-config or text/x-shellscript. Defaults to " + cloudinitDefaultContentType},
+						"content":      schema.StringAttribute{Required: true, Description: "Content of the part"},
+						"filename":     schema.StringAttribute{Optional: true, Description: "Filename of the part"},
+						"merge_type":   schema.StringAttribute{Optional: true, Description: "How cloud-init merges the part with the previous parts, eg: list(append)+dict(recurse_array)+str()"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *CloudinitConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read `cloudinit_config` data source")
+	var state CloudinitConfigDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gzipped := state.Gzip.ValueBool()
+	encode := state.Base64Encode.ValueBool() || (gzipped && state.Base64Encode.IsNull())
+	if gzipped && !encode {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base64_encode"),
+			"Invalid cloudinit_config",
+			"A gzip compressed document must be base64 encoded to be used as user data",
+		)
+		return
+	}
+
+	rendered, err := renderCloudinitConfig(state.Parts, state.Boundary.ValueString(), gzipped, encode)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to render `cloudinit_config`",
+			err.Error(),
+		)
+		return
+	}
+
+	sum := sha256.Sum256([]byte(rendered))
+	state.ID = types.StringValue(hex.EncodeToString(sum[:]))
+	state.Rendered = types.StringValue(rendered)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Finished reading `cloudinit_config` data source", map[string]any{"success": true})
+}
+
+// renderCloudinitConfig returns the parts as a multipart/mixed document, as read by cloud-init.
+func renderCloudinitConfig(parts []CloudinitConfigPart, boundary string, gzipped, encode bool) (string, error) {
+	if boundary == "" {
+		boundary = cloudinitDefaultBoundary
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\nMIME-Version: 1.0\r\n\r\n", boundary)
+	writer := multipart.NewWriter(&buf)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return "", err
+	}
+	for _, part := range parts {
+		contentType := part.ContentType.ValueString()
+		if contentType == "" {
+			contentType = cloudinitDefaultContentType
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", contentType)
+		header.Set("Content-Transfer-Encoding", "7bit")
+		header.Set("MIME-Version", "1.0")
+		if filename := part.Filename.ValueString(); filename != "" {
+			header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		}
+		if mergeType := part.MergeType.ValueString(); mergeType != "" {
+			header.Set("X-Merge-Type", mergeType)
+		}
+
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return "", err
+		}
+		if _, err := w.Write([]byte(part.Content.ValueString())); err != nil {
+			return "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	document := buf.Bytes()
+	if gzipped {
+		var compressed bytes.Buffer
+		gz := gzip.NewWriter(&compressed)
+		if _, err := gz.Write(document); err != nil {
+			return "", err
+		}
+		if err := gz.Close(); err != nil {
+			return "", err
+		}
+		document = compressed.Bytes()
+	}
+	if encode {
+		return base64.StdEncoding.EncodeToString(document), nil
+	}
+	return string(document), nil
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCloudinitConfigDataSource(t *testing.T) {
+	resourceName := "data.utho_cloudinit_config.example"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "utho_cloudinit_config" "example" {
+	part {
+		content_type = "text/cloud-config"
+		content      = "packages: [nginx]"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "rendered"),
+				),
+			},
+		},
+	})
+}
+
+func TestRenderCloudinitConfig(t *testing.T) {
+	parts := []CloudinitConfigPart{
+		{ContentType: types.StringValue("text/cloud-config"), Content: types.StringValue("packages: [nginx]")},
+		{Content: types.StringValue("#!/bin/sh\necho ok"), Filename: types.StringValue("setup.sh"), MergeType: types.StringValue("list(append)")},
+	}
+
+	rendered, err := renderCloudinitConfig(parts, "", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Content-Type: multipart/mixed; boundary=\"MIMEBOUNDARY\"\r\n",
+		"--MIMEBOUNDARY\r\n",
+		"Content-Type: text/cloud-config\r\n",
+		"packages: [nginx]",
+		"Content-Type: text/plain\r\n",
+		"Content-Disposition: attachment; filename=\"setup.sh\"\r\n",
+		"X-Merge-Type: list(append)\r\n",
+		"--MIMEBOUNDARY--",
+	} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("rendered document does not contain %q:\n%s", want, rendered)
+		}
+	}
+
+	again, err := renderCloudinitConfig(parts, "", false, false)
+	if err != nil || again != rendered {
+		t.Error("rendering the same parts should give the same document")
+	}
+
+	encoded, err := renderCloudinitConfig(parts, "", true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil || string(decompressed) != rendered {
+		t.Errorf("gzip document = %q, %v, want %q", decompressed, err, rendered)
+	}
+}
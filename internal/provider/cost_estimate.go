@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// The cost of a cloud instance is estimated from the plan pricing when it is planned,
+// so the cost of a change is known before it is applied.
+
+const (
+	// hoursPerMonth is the number of hours billed for a month of hourly usage.
+	hoursPerMonth = 730
+	// backupCostRate is the share of the plan price charged for weekly backups.
+	backupCostRate = 0.2
+)
+
+// billingCycleMonths is the number of months paid up front for each billing cycle, hourly instances are billed as used.
+var billingCycleMonths = map[string]int{
+	"monthly": 1,
+	"3month":  3,
+	"6month":  6,
+	"12month": 12,
+}
+
+// cloudInstanceCost returns the hourly and monthly cost of an instance of the plan,
+// ok is false when the plan has no monthly price.
+func cloudInstanceCost(plan cloudPlan, backup bool) (hourly, monthly float64, ok bool) {
+	monthly, err := strconv.ParseFloat(strings.TrimSpace(plan.Monthly), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if backup {
+		monthly += monthly * backupCostRate
+	}
+	return monthly / hoursPerMonth, monthly, true
+}
+
+// cloudInstanceCostMessage describes the estimated cost of an instance billed on the billing cycle.
+func cloudInstanceCostMessage(name string, hourly, monthly float64, billingcycle string) string {
+	message := fmt.Sprintf("Cloud instance %q is estimated to cost %.4f per hour, %.2f per month.", name, hourly, monthly)
+	if months, ok := billingCycleMonths[billingcycle]; ok {
+		message += fmt.Sprintf(" The %s billing cycle charges %.2f up front.", billingcycle, monthly*float64(months))
+	} else {
+		message += " It is billed hourly as used."
+	}
+	return message
+}
+
+// estimateCloudInstanceCost sets the planned estimated_monthly_cost of a new or replaced cloud instance and warns with the estimate.
+// The plan fails when the estimate exceeds the provider max_monthly_cost.
+func estimateCloudInstanceCost(ctx context.Context, client utho.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if client == nil || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the estimate is kept from the state by UseStateForUnknown, it is only unknown when the instance is created or imported
+	// and when it is replaced, as terraform plans a replacement again without the state
+	var plan CloudInstanceResourceModel
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("estimated_monthly_cost"), &plan.EstimatedMonthlyCost)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &plan.Name)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("dcslug"), &plan.Dcslug)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("planid"), &plan.Planid)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("enablebackup"), &plan.Enablebackup)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("billingcycle"), &plan.Billingcycle)...)
+	if resp.Diagnostics.HasError() || !plan.EstimatedMonthlyCost.IsUnknown() {
+		return
+	}
+	if plan.Planid.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("estimated_monthly_cost"), types.Float64Null())...)
+		return
+	}
+	if plan.Dcslug.IsUnknown() || plan.Planid.IsUnknown() || plan.Enablebackup.IsUnknown() || plan.Billingcycle.IsUnknown() {
+		return
+	}
+
+	plans, err := planLookupsFor(client).cloudPlans(client, plan.Dcslug.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("estimated_monthly_cost"), "Unable to estimate cost", "Could not list utho plans: "+err.Error())
+		return
+	}
+	var hourly, monthly float64
+	found := false
+	for _, cloudPlan := range plans {
+		if cloudPlan.ID == plan.Planid.ValueString() {
+			hourly, monthly, found = cloudInstanceCost(cloudPlan, plan.Enablebackup.ValueBool())
+			break
+		}
+	}
+	if !found {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("estimated_monthly_cost"), types.Float64Value(monthly))...)
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("estimated_monthly_cost"),
+		"Estimated cloud instance cost",
+		cloudInstanceCostMessage(plan.Name.ValueString(), hourly, monthly, plan.Billingcycle.ValueString()),
+	)
+
+	if pc, ok := client.(*providerClient); ok && !pc.maxMonthlyCost.IsNull() && !pc.maxMonthlyCost.IsUnknown() && monthly > pc.maxMonthlyCost.ValueFloat64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("planid"),
+			"Monthly cost over budget",
+			fmt.Sprintf("Cloud instance %q is estimated to cost %.2f per month, more than the provider max_monthly_cost of %.2f. Choose a smaller plan or raise the budget",
+				plan.Name.ValueString(), monthly, pc.maxMonthlyCost.ValueFloat64()),
+		)
+	}
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+func TestCloudInstanceCost(t *testing.T) {
+	plan := cloudPlan{Pricing: utho.Pricing{ID: "10045", Monthly: "730"}}
+
+	hourly, monthly, ok := cloudInstanceCost(plan, false)
+	if !ok || monthly != 730 || hourly != 1 {
+		t.Errorf("cloudInstanceCost() = %v, %v, %v, want 1, 730, true", hourly, monthly, ok)
+	}
+
+	hourly, monthly, ok = cloudInstanceCost(plan, true)
+	if !ok || math.Abs(monthly-876) > 1e-9 || math.Abs(hourly-1.2) > 1e-9 {
+		t.Errorf("cloudInstanceCost() with backup = %v, %v, %v, want 1.2, 876, true", hourly, monthly, ok)
+	}
+
+	if _, _, ok := cloudInstanceCost(cloudPlan{Pricing: utho.Pricing{ID: "10045"}}, false); ok {
+		t.Error("cloudInstanceCost() of a plan without monthly price should not be ok")
+	}
+}
+
+func TestCloudInstanceCostMessage(t *testing.T) {
+	tests := []struct {
+		billingcycle string
+		want         string
+	}{
+		{"", "billed hourly"},
+		{"hourly", "billed hourly"},
+		{"monthly", "charges 100.00 up front"},
+		{"12month", "charges 1200.00 up front"},
+	}
+	for _, test := range tests {
+		message := cloudInstanceCostMessage("web", 100.0/hoursPerMonth, 100, test.billingcycle)
+		if !strings.Contains(message, "0.1370 per hour, 100.00 per month") || !strings.Contains(message, test.want) {
+			t.Errorf("cloudInstanceCostMessage(%q) = %q, want it to contain %q", test.billingcycle, message, test.want)
+		}
+	}
+}
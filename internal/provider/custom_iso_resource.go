@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// implement resource interfaces.
+var (
+	_ resource.Resource                = &CustomIsoResource{}
+	_ resource.ResourceWithConfigure   = &CustomIsoResource{}
+	_ resource.ResourceWithImportState = &CustomIsoResource{}
+	_ resource.ResourceWithModifyPlan  = &CustomIsoResource{}
+)
+
+// NewCustomIsoResource is a helper function to simplify the provider implementation.
+func NewCustomIsoResource() resource.Resource {
+	return &CustomIsoResource{}
+}
+
+// CustomIsoResource is the resource implementation.
+type CustomIsoResource struct {
+	client utho.Client
+}
+
+type CustomIsoResourceModel struct {
+	ID       types.String  `tfsdk:"id"`
+	Name     types.String  `tfsdk:"name"`
+	Dcslug   types.String  `tfsdk:"dcslug"`
+	URL      types.String  `tfsdk:"url"`
+	File     types.String  `tfsdk:"file"`
+	Size     types.Float64 `tfsdk:"size"`
+	Download types.String  `tfsdk:"download"`
+	AddedAt  types.String  `tfsdk:"added_at"`
+}
+
+// customIsoPollInterval is how often the iso list is read while waiting for a download.
+var customIsoPollInterval = 10 * time.Second
+
+// customIsoDownloadTimeout is how long an iso may take to download.
+const customIsoDownloadTimeout = 30 * time.Minute
+
+// errIsoNotFound is returned when the iso is not in the iso list.
+var errIsoNotFound = errors.New("iso not found")
+
+// Metadata returns the resource type name.
+func (s *CustomIsoResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_iso"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *CustomIsoResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(utho.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected CustomIso Data Source Configure Type",
+			fmt.Sprintf("Expected utho.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// Schema defines the schema for the resource.
+func (s *CustomIsoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Uploads an iso image from a url, it can be mounted on cloud instances in the same zone with `mounted_iso_id`. Creating the resource waits for the download to finish.",
+		Attributes: map[string]schema.Attribute{
+			"id":     schema.StringAttribute{Computed: true, Description: "Iso id", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"name":   schema.StringAttribute{Required: true, Description: "Name of the iso", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"dcslug": schema.StringAttribute{Required: true, Description: "Zone dcslug to upload the iso to eg: innoida", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"url": schema.StringAttribute{Required: true, Description: "Public url the iso is downloaded from. Changing the url uploads a new iso",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIf(requiresReplaceUnlessImported,
+					"Replaces the iso unless it was imported", "Replaces the iso unless it was imported")},
+			},
+			"file":     schema.StringAttribute{Computed: true, Description: "Iso file name", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"size":     schema.Float64Attribute{Computed: true, Description: "Size"},
+			"download": schema.StringAttribute{Computed: true, Description: "Download progress in percent"},
+			"added_at": schema.StringAttribute{Computed: true, Description: "Added At", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+		},
+	}
+}
+
+// ModifyPlan validates the planned dcslug against the utho api.
+func (s *CustomIsoResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validateDcslugPlan(ctx, s.client, req, resp)
+}
+
+// Import using iso id as the attribute
+func (s *CustomIsoResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Create a new resource.
+func (s *CustomIsoResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "create custom iso")
+	// Retrieve values from plan
+	var plan CustomIsoResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	isoRequest := utho.CreateISOParams{
+		Dcslug: plan.Dcslug.ValueString(),
+		URL:    plan.URL.ValueString(),
+		Name:   plan.Name.ValueString(),
+	}
+	tflog.Debug(ctx, "send create custom iso request")
+	createIso, err := s.client.ISO().Create(isoRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating custom iso",
+			"Could not create custom iso, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	iso, err := s.waitForDownload(ctx, createIso.ID, plan.Name.ValueString(), plan.Dcslug.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating custom iso",
+			"Custom iso "+plan.Name.ValueString()+" did not finish downloading: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = types.StringValue(createIso.ID)
+	if plan.ID.ValueString() == "" {
+		plan.ID = types.StringValue(iso.File)
+	}
+	plan.File = types.StringValue(iso.File)
+	plan.Size = types.Float64Value(iso.Size)
+	plan.Download = types.StringValue(iso.Download)
+	plan.AddedAt = types.StringValue(iso.AddedAt)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish create custom iso")
+}
+
+// Read resource information.
+func (s *CustomIsoResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "read custom iso")
+
+	// Get current state
+	var state CustomIsoResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "send list iso request")
+	isos, err := s.client.ISO().List()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho custom iso",
+			"Could not read utho custom iso "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	iso, err := findIso(isos, state.ID.ValueString(), state.Name.ValueString(), state.Dcslug.ValueString())
+	if err != nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Overwrite items with refreshed state
+	state.Name = types.StringValue(iso.Name)
+	if state.Dcslug.IsNull() {
+		state.Dcslug = types.StringValue(iso.Dc)
+	}
+	state.File = types.StringValue(iso.File)
+	state.Size = types.Float64Value(iso.Size)
+	state.Download = types.StringValue(iso.Download)
+	state.AddedAt = types.StringValue(iso.AddedAt)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish get custom iso request")
+}
+
+func (s *CustomIsoResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// the url is not returned by the api, imported isos adopt the configured url
+	resp.State.Raw = req.State.Raw.Copy()
+	adoptPlannedAttributes(ctx, req, resp, "url")
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (s *CustomIsoResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "delete custom iso")
+	// Get current state
+	var state CustomIsoResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "send delete custom iso request")
+	// delete custom iso
+	_, err := s.client.ISO().Delete(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleteing utho custom iso",
+			"Could not delete utho custom iso "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// requiresReplaceUnlessImported replaces the iso when the url changes, imported isos have no url in the state.
+func requiresReplaceUnlessImported(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull()
+}
+
+// waitForDownload waits until the iso is downloaded.
+func (s *CustomIsoResource) waitForDownload(ctx context.Context, id, name, dcslug string) (*utho.ISO, error) {
+	ctx, cancel := context.WithTimeout(ctx, customIsoDownloadTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(customIsoPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		isos, err := s.client.ISO().List()
+		if err != nil {
+			return nil, err
+		}
+		iso, err := findIso(isos, id, name, dcslug)
+		if errors.Is(err, errIsoNotFound) {
+			// the iso is listed once the download starts
+			continue
+		}
+		if strings.TrimSpace(iso.Download) == "100" {
+			return iso, nil
+		}
+	}
+}
+
+// findIso returns the iso with the id, the iso list has no ids so the id is matched with the file name.
+// Isos created without an id are matched by name and zone.
+func findIso(isos []utho.ISO, id, name, dcslug string) (*utho.ISO, error) {
+	for i := range isos {
+		if id != "" && isos[i].File == id {
+			return &isos[i], nil
+		}
+	}
+	for i := range isos {
+		if isos[i].Name == name && isos[i].Dc == dcslug {
+			return &isos[i], nil
+		}
+	}
+	return nil, errIsoNotFound
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+func TestAccCustomIsoResource(t *testing.T) {
+	resourceName := "utho_custom_iso.example"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "utho_custom_iso" "example" {
+	name   = "example-iso"
+	dcslug = "inmumbaizone2"
+	url    = "https://releases.ubuntu.com/22.04/ubuntu-22.04.4-live-server-amd64.iso"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "example-iso"),
+					resource.TestCheckResourceAttr(resourceName, "download", "100"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "file"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"url"},
+			},
+		},
+	})
+}
+
+func TestFindIso(t *testing.T) {
+	isos := []utho.ISO{
+		{Name: "rescue", File: "rescue-1.iso", Dc: "innoida"},
+		{Name: "rescue", File: "rescue-2.iso", Dc: "inmumbaizone2"},
+	}
+
+	for name, tc := range map[string]struct {
+		id, name, dcslug string
+		want             string
+	}{
+		"by id":           {id: "rescue-2.iso", want: "rescue-2.iso"},
+		"by name in zone": {name: "rescue", dcslug: "inmumbaizone2", want: "rescue-2.iso"},
+		"unknown id":      {id: "other.iso", name: "rescue", dcslug: "innoida", want: "rescue-1.iso"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			iso, err := findIso(isos, tc.id, tc.name, tc.dcslug)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if iso.File != tc.want {
+				t.Errorf("findIso() = %q, want %q", iso.File, tc.want)
+			}
+		})
+	}
+
+	if _, err := findIso(isos, "other.iso", "other", "innoida"); !errors.Is(err, errIsoNotFound) {
+		t.Errorf("findIso() error = %v, want %v", err, errIsoNotFound)
+	}
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// NewDatacentersDataSource is a helper function to simplify the provider implementation.
+func NewDatacentersDataSource() datasource.DataSource {
+	return &listDataSource{
+		typeName:    "_datacenters",
+		attribute:   "datacenters",
+		description: "Zones, use the slug as the dcslug of a resource",
+		itemAttributes: map[string]schema.Attribute{
+			"slug":           schema.StringAttribute{Computed: true, Description: "Zone dcslug eg: innoida"},
+			"location":       schema.StringAttribute{Computed: true, Description: "location"},
+			"country":        schema.StringAttribute{Computed: true, Description: "country"},
+			"cc":             schema.StringAttribute{Computed: true, Description: "country code"},
+			"status":         schema.StringAttribute{Computed: true, Description: "status"},
+			"kubernetes":     schema.BoolAttribute{Computed: true, Description: "Kubernetes clusters are available in the zone"},
+			"object_storage": schema.BoolAttribute{Computed: true, Description: "Object storage is available in the zone"},
+			"gpu":            schema.BoolAttribute{Computed: true, Description: "GPU plans are available in the zone"},
+		},
+		list: listDatacenterItems,
+	}
+}
+
+func listDatacenterItems(client utho.Client) ([]map[string]attr.Value, error) {
+	datacenters, err := listDatacenters(client)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]attr.Value, 0, len(datacenters))
+	for _, datacenter := range datacenters {
+		items = append(items, map[string]attr.Value{
+			"slug":           types.StringValue(datacenter.Slug),
+			"location":       types.StringValue(datacenter.Location),
+			"country":        types.StringValue(datacenter.Country),
+			"cc":             types.StringValue(datacenter.Cc),
+			"status":         types.StringValue(datacenter.Status),
+			"kubernetes":     types.BoolValue(datacenterFeature(datacenter.Kubernetes)),
+			"object_storage": types.BoolValue(datacenterFeature(datacenter.ObjectStorage)),
+			"gpu":            types.BoolValue(datacenterFeature(datacenter.Gpu)),
+		})
+	}
+	return items, nil
+}
+
+// datacenterFeature maps the api feature flags eg: "1" or "true".
+func datacenterFeature(value string) bool {
+	return value == "1" || strings.EqualFold(value, "true") || strings.EqualFold(value, "yes")
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatacentersDataSource(t *testing.T) {
+	resourceName := "data.utho_datacenters.example"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "utho_datacenters" "example" {
+	filter {
+		name   = "slug"
+		values = ["innoida"]
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "datacenters.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "datacenters.0.slug", "innoida"),
+					resource.TestCheckResourceAttrSet(resourceName, "datacenters.0.location"),
+					resource.TestCheckResourceAttrSet(resourceName, "datacenters.0.country"),
+				),
+			},
+		},
+	})
+}
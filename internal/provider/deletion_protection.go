@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Deletion protection fails the plan of a protected object that is replaced, so a replacement
+// created before the object is destroyed is never created. Delete checks it against the state as well,
+// which covers destroy.
+
+// deletionProtectionAttribute returns the deletion_protection attribute of a resource of the kind.
+func deletionProtectionAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:    true,
+		Description: "Prevent the " + kind + " from being deleted or replaced. It must be set to false in a prior apply before the " + kind + " can be deleted. Defaults to false",
+	}
+}
+
+// checkDeletionProtection fails the delete or replacement of a protected object of the kind.
+func checkDeletionProtection(ctx context.Context, state tfsdk.State, kind, id string) diag.Diagnostics {
+	var deletionProtection types.Bool
+	diags := state.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)
+	if diags.HasError() || !deletionProtection.ValueBool() {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("deletion_protection"),
+		"Deletion protection enabled",
+		fmt.Sprintf("The utho %s %s has deletion protection enabled and can not be deleted or replaced. "+
+			"Set deletion_protection to false and apply before deleting it.", kind, id),
+	)
+	return diags
+}
+
+// checkDeletionProtectionPlan fails the plan when a protected object of the kind is replaced,
+// idAttribute is the attribute that identifies the object.
+func checkDeletionProtectionPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, kind, idAttribute string) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+	var id types.String
+	var protected types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	if resp.Diagnostics.HasError() || !protected.ValueBool() {
+		return
+	}
+	replaced, diags := plannedReplacement(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(replaced) == 0 {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(idAttribute), &id)...)
+	resp.Diagnostics.AddAttributeError(
+		path.Root("deletion_protection"),
+		"Deletion protection enabled",
+		fmt.Sprintf("The utho %s %s has deletion protection enabled and changes to %s replace it. "+
+			"Set deletion_protection to false and apply before replacing it.", kind, id.ValueString(), replaced),
+	)
+}
+
+// plannedReplacement returns the attributes whose plan modifiers replace the object.
+// The resource ModifyPlan does not see the replacements of the attribute plan modifiers, so they are run again.
+// Only top level string and bool attributes replace the protected resources.
+func plannedReplacement(ctx context.Context, req resource.ModifyPlanRequest) (path.Paths, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	replaced := path.Paths{}
+	for name, attribute := range req.Plan.Schema.GetAttributes() {
+		attributePath := path.Root(name)
+		switch attribute := attribute.(type) {
+		case schema.StringAttribute:
+			var config, plan, state types.String
+			diags.Append(req.Config.GetAttribute(ctx, attributePath, &config)...)
+			diags.Append(req.Plan.GetAttribute(ctx, attributePath, &plan)...)
+			diags.Append(req.State.GetAttribute(ctx, attributePath, &state)...)
+			for _, modifier := range attribute.PlanModifiers {
+				modifierReq := planmodifier.StringRequest{
+					Path: attributePath, PathExpression: attributePath.Expression(),
+					Config: req.Config, ConfigValue: config, Plan: req.Plan, PlanValue: plan, State: req.State, StateValue: state,
+				}
+				modifierResp := &planmodifier.StringResponse{PlanValue: plan}
+				modifier.PlanModifyString(ctx, modifierReq, modifierResp)
+				diags.Append(modifierResp.Diagnostics...)
+				if modifierResp.RequiresReplace {
+					replaced.Append(attributePath)
+					break
+				}
+			}
+		case schema.BoolAttribute:
+			var config, plan, state types.Bool
+			diags.Append(req.Config.GetAttribute(ctx, attributePath, &config)...)
+			diags.Append(req.Plan.GetAttribute(ctx, attributePath, &plan)...)
+			diags.Append(req.State.GetAttribute(ctx, attributePath, &state)...)
+			for _, modifier := range attribute.PlanModifiers {
+				modifierReq := planmodifier.BoolRequest{
+					Path: attributePath, PathExpression: attributePath.Expression(),
+					Config: req.Config, ConfigValue: config, Plan: req.Plan, PlanValue: plan, State: req.State, StateValue: state,
+				}
+				modifierResp := &planmodifier.BoolResponse{PlanValue: plan}
+				modifier.PlanModifyBool(ctx, modifierReq, modifierResp)
+				diags.Append(modifierResp.Diagnostics...)
+				if modifierResp.RequiresReplace {
+					replaced.Append(attributePath)
+					break
+				}
+			}
+		}
+	}
+	slices.SortFunc(replaced, func(a, b path.Path) int { return strings.Compare(a.String(), b.String()) })
+	return replaced, diags
+}
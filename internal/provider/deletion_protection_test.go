@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCheckDeletionProtection(t *testing.T) {
+	ctx := context.Background()
+	for _, r := range []func() fwresource.Resource{NewCloudInstanceResource, NewLoadbalancerResource, NewVpcResource, NewDomainResource} {
+		schemaResp := &fwresource.SchemaResponse{}
+		r().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+		for deletionProtection, expectError := range map[any]bool{nil: false, false: false, true: true} {
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: testObject(schemaResp.Schema, map[string]tftypes.Value{
+				"deletion_protection": tftypes.NewValue(tftypes.Bool, deletionProtection),
+			})}
+
+			diags := checkDeletionProtection(ctx, state, "object", "1")
+			if diags.HasError() != expectError {
+				t.Errorf("%T with deletion_protection %v: expected error %t, got %v", r(), deletionProtection, expectError, diags)
+			}
+		}
+	}
+}
+
+func TestCheckDeletionProtectionPlan(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewDomainResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	object := func(domain string, deletionProtection bool) tftypes.Value {
+		return testObject(schemaResp.Schema, map[string]tftypes.Value{
+			"domain":              tftypes.NewValue(tftypes.String, domain),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, deletionProtection),
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		state, plan tftypes.Value
+		expectError bool
+	}{
+		"replaced protected":      {state: object("example.com", true), plan: object("example.org", true), expectError: true},
+		"replaced unprotected":    {state: object("example.com", false), plan: object("example.org", false)},
+		"protection disabled":     {state: object("example.com", true), plan: object("example.com", false)},
+		"replaced while disabled": {state: object("example.com", true), plan: object("example.org", false), expectError: true},
+		"created":                 {state: tftypes.NewValue(objectType, nil), plan: object("example.org", true)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tc.plan},
+				Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: tc.plan},
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tc.state},
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+			checkDeletionProtectionPlan(ctx, req, resp, "domain", "domain")
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Errorf("expected error %t, got %v", tc.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+// NewDomainDataSource is a helper function to simplify the provider implementation.
+// The domain name is its id, so it is the only lookup.
+func NewDomainDataSource() datasource.DataSource {
+	return &resourceDataSource{
+		resource:    NewDomainResource,
+		idAttribute: "domain",
+	}
+}
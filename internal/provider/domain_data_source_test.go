@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDomainDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "utho_domain" "example" {
+	domain = "example-data-source-utho.com"
+}
+
+data "utho_domain" "example" {
+	domain = utho_domain.example.domain
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utho_domain.example", "domain", "example-data-source-utho.com"),
+				),
+			},
+		},
+	})
+}
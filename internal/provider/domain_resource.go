@@ -19,6 +19,7 @@ var (
 	_ resource.Resource                = &DomainResource{}
 	_ resource.ResourceWithConfigure   = &DomainResource{}
 	_ resource.ResourceWithImportState = &DomainResource{}
+	_ resource.ResourceWithModifyPlan  = &DomainResource{}
 )
 
 // NewDomainResource is a helper function to simplify the provider implementation.
@@ -34,8 +35,9 @@ type (
 
 	// DomainResource is the model implementation.
 	DomainResourceModel struct {
-		Domain  types.String `tfsdk:"domain"`
-		Nspoint types.String `tfsdk:"nspoint"`
+		Domain             types.String `tfsdk:"domain"`
+		Nspoint            types.String `tfsdk:"nspoint"`
+		DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 	}
 )
 
@@ -73,11 +75,17 @@ func (s *DomainResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 				Description: "Domain name",
 			},
-			"nspoint": schema.StringAttribute{Computed: true, Description: "nspoint"},
+			"nspoint":             schema.StringAttribute{Computed: true, Description: "nspoint"},
+			"deletion_protection": deletionProtectionAttribute("domain"),
 		},
 	}
 }
 
+// ModifyPlan fails the plan when a protected domain is replaced.
+func (s *DomainResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkDeletionProtectionPlan(ctx, req, resp, "domain", "domain")
+}
+
 // Import using domain as the attribute
 func (s *DomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("domain"), req, resp)
@@ -110,7 +118,8 @@ func (s *DomainResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Map response body to schema and populate Computed attribute values
 	plan = DomainResourceModel{
-		Domain: types.StringValue(plan.Domain.ValueString()),
+		Domain:             types.StringValue(plan.Domain.ValueString()),
+		DeletionProtection: plan.DeletionProtection,
 	}
 
 	// Set state to fully populated data
@@ -147,8 +156,9 @@ func (s *DomainResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	// Overwrite items with refreshed state
 	state = DomainResourceModel{
-		Domain:  types.StringValue(domain.Domain),
-		Nspoint: types.StringValue(domain.Nspoint),
+		Domain:             types.StringValue(domain.Domain),
+		Nspoint:            types.StringValue(domain.Nspoint),
+		DeletionProtection: state.DeletionProtection,
 	}
 
 	// Set refreshed state
@@ -161,7 +171,9 @@ func (s *DomainResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (s *DomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// updating resource is not supported
+	// other changes replace the domain
+	resp.State.Raw = req.State.Raw.Copy()
+	adoptPlannedAttributes(ctx, req, resp, "deletion_protection")
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -174,6 +186,10 @@ func (s *DomainResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, "domain", state.Domain.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	tflog.Debug(ctx, "send delete domain request")
 	// delete domain
 	_, err := s.client.Domain().DeleteDomain(state.Domain.ValueString())
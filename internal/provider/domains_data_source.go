@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// NewDomainsDataSource is a helper function to simplify the provider implementation.
+func NewDomainsDataSource() datasource.DataSource {
+	return &listDataSource{
+		typeName:    "_domains",
+		attribute:   "domains",
+		description: "Domains",
+		itemAttributes: map[string]schema.Attribute{
+			"domain":          schema.StringAttribute{Computed: true, Description: "Domain name"},
+			"nspoint":         schema.StringAttribute{Computed: true, Description: "nspoint"},
+			"status":          schema.StringAttribute{Computed: true, Description: "status"},
+			"dnsrecord_count": schema.StringAttribute{Computed: true, Description: "Number of dns records"},
+			"created_at":      schema.StringAttribute{Computed: true, Description: "created_at"},
+		},
+		list: listDomains,
+	}
+}
+
+func listDomains(client utho.Client) ([]map[string]attr.Value, error) {
+	domains, err := client.Domain().ListDomains()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]attr.Value, 0, len(domains))
+	for _, domain := range domains {
+		items = append(items, map[string]attr.Value{
+			"domain":          types.StringValue(domain.Domain),
+			"nspoint":         types.StringValue(domain.Nspoint),
+			"status":          types.StringValue(domain.Status),
+			"dnsrecord_count": types.StringValue(domain.DnsrecordCount),
+			"created_at":      types.StringValue(domain.CreatedAt),
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// NewFirewallDataSource is a helper function to simplify the provider implementation.
+// The firewall is looked up by id or by name.
+func NewFirewallDataSource() datasource.DataSource {
+	return &resourceDataSource{
+		resource:    NewFirewallResource,
+		idAttribute: "id",
+		lookup:      lookupFirewall,
+		dcslug:      false,
+	}
+}
+
+func lookupFirewall(client utho.Client, name, _ string) ([]string, error) {
+	firewalls, err := client.Firewall().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, firewall := range firewalls {
+		if firewall.Name == name {
+			ids = append(ids, firewall.ID)
+		}
+	}
+	return ids, nil
+}
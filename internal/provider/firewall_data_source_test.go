@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFirewallDataSource(t *testing.T) {
+	resourceName := "utho_firewall.example"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "utho_firewall" "example" {
+	name = "example-data-source"
+}
+
+data "utho_firewall" "by_id" {
+	id = utho_firewall.example.id
+}
+
+data "utho_firewall" "by_name" {
+	name = utho_firewall.example.name
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.utho_firewall.by_id", "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair("data.utho_firewall.by_name", "id", resourceName, "id"),
+				),
+			},
+		},
+	})
+}
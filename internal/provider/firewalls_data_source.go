@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// NewFirewallsDataSource is a helper function to simplify the provider implementation.
+func NewFirewallsDataSource() datasource.DataSource {
+	return &listDataSource{
+		typeName:    "_firewalls",
+		attribute:   "firewalls",
+		description: "Firewalls",
+		itemAttributes: map[string]schema.Attribute{
+			"id":           schema.StringAttribute{Computed: true, Description: "id"},
+			"name":         schema.StringAttribute{Computed: true, Description: "Name of the firewall"},
+			"created_at":   schema.StringAttribute{Computed: true, Description: "created_at"},
+			"rulecount":    schema.StringAttribute{Computed: true, Description: "rulecount"},
+			"serverscount": schema.StringAttribute{Computed: true, Description: "serverscount"},
+		},
+		list: listFirewalls,
+	}
+}
+
+func listFirewalls(client utho.Client) ([]map[string]attr.Value, error) {
+	firewalls, err := client.Firewall().List()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]attr.Value, 0, len(firewalls))
+	for _, firewall := range firewalls {
+		items = append(items, map[string]attr.Value{
+			"id":           types.StringValue(firewall.ID),
+			"name":         types.StringValue(firewall.Name),
+			"created_at":   types.StringValue(firewall.CreatedAt),
+			"rulecount":    types.StringValue(firewall.Rulecount),
+			"serverscount": types.StringValue(firewall.Serverscount),
+		})
+	}
+	return items, nil
+}
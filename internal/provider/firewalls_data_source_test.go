@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFirewallsDataSource(t *testing.T) {
+	resourceName := "data.utho_firewalls.example"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "utho_firewall" "example" {
+	name = "example-firewalls"
+}
+
+data "utho_firewalls" "example" {
+	filter {
+		name   = "name"
+		values   = [utho_firewall.example.name]
+	}
+
+	sort {
+		key       = "created_at"
+		direction = "desc"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "firewalls.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "firewalls.0.id", "utho_firewall.example", "id"),
+				),
+			},
+		},
+	})
+}
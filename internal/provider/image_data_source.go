@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+var (
+	_ datasource.DataSource              = &ImageDataSource{}
+	_ datasource.DataSourceWithConfigure = &ImageDataSource{}
+)
+
+type ImageDataSource struct {
+	client utho.Client
+}
+
+type ImageDataSourceConfigModel struct {
+	Distribution types.String `tfsdk:"distribution"`
+	VersionRegex types.String `tfsdk:"version_regex"`
+	Architecture types.String `tfsdk:"architecture"`
+	MostRecent   types.Bool   `tfsdk:"most_recent"`
+	Distro       types.String `tfsdk:"distro"`
+	Version      types.String `tfsdk:"version"`
+	Image        types.String `tfsdk:"image"`
+	Cost         types.Int64  `tfsdk:"cost"`
+}
+
+func NewImageDataSource() datasource.DataSource {
+	return &ImageDataSource{}
+}
+
+func (*ImageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image"
+}
+
+// Schema defines the schema for the data source.
+func (d *ImageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Selects exactly one OS image, reading fails when no image or several images match.",
+		Attributes: map[string]schema.Attribute{
+			"distribution":  schema.StringAttribute{Optional: true, Computed: true, Description: imageDistributionDescription},
+			"version_regex": schema.StringAttribute{Optional: true, Description: imageVersionRegexDescription},
+			"architecture":  schema.StringAttribute{Optional: true, Computed: true, Description: imageArchitectureDescription},
+			"most_recent":   schema.BoolAttribute{Optional: true, Description: "Select the image with the highest version when several images match"},
+			"distro":        schema.StringAttribute{Computed: true, Description: "distro"},
+			"version":       schema.StringAttribute{Computed: true, Description: "version"},
+			"image":         schema.StringAttribute{Computed: true, Description: "Image name, use it as the image of a cloud instance"},
+			"cost":          schema.Int64Attribute{Computed: true, Description: "cost"},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ImageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(utho.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Image Data Source Configure Type",
+			fmt.Sprintf("Expected utho.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *ImageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read `image` data source")
+	var state ImageDataSourceConfigModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get images
+	images, err := d.client.CloudInstances().ListOsImages()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list `images`",
+			err.Error(),
+		)
+		return
+	}
+
+	images, diags = filterImages(images, state.Distribution, state.VersionRegex, state.Architecture, state.MostRecent)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	switch len(images) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"Unable to find `image`",
+			"No image matches the distribution, version_regex and architecture filters",
+		)
+		return
+	case 1:
+	default:
+		var names []string
+		for _, image := range images {
+			names = append(names, image.Image)
+		}
+		resp.Diagnostics.AddError(
+			"Multiple `image` matches",
+			fmt.Sprintf("%d images match the filters: %s. Narrow down the filters or set most_recent", len(images), strings.Join(names, ", ")),
+		)
+		return
+	}
+
+	// Map response body to model
+	image := imageDataSourceModel(images[0])
+	if state.Distribution.IsNull() {
+		state.Distribution = image.Distribution
+	}
+	if state.Architecture.IsNull() {
+		state.Architecture = image.Architecture
+	}
+	state.Distro = image.Distro
+	state.Version = image.Version
+	state.Image = image.Image
+	state.Cost = image.Cost
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Finished reading `image` data source", map[string]any{"success": true})
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccImageDataSource(t *testing.T) {
+	resourceName := "data.utho_image.example"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "utho_image" "example" {
+	distribution = "ubuntu"
+	architecture = "x86_64"
+	most_recent  = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "distribution", "ubuntu"),
+					resource.TestCheckResourceAttrSet(resourceName, "image"),
+					resource.TestCheckResourceAttrSet(resourceName, "version"),
+				),
+			},
+			{
+				Config: providerConfig + `
+data "utho_image" "example" {
+	distribution = "ubuntu"
+}
+`,
+				ExpectError: regexp.MustCompile("Multiple `image` matches"),
+			},
+		},
+	})
+}
@@ -3,9 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/uthoplatforms/utho-go/utho"
@@ -20,13 +26,18 @@ type ImagesDataSource struct {
 	client utho.Client
 }
 type ImagesDataSourceModel struct {
-	Images []ImageDataSourceModel `tfsdk:"images"`
+	Distribution types.String           `tfsdk:"distribution"`
+	VersionRegex types.String           `tfsdk:"version_regex"`
+	Architecture types.String           `tfsdk:"architecture"`
+	MostRecent   types.Bool             `tfsdk:"most_recent"`
+	Images       []ImageDataSourceModel `tfsdk:"images"`
 }
 type ImageDataSourceModel struct {
 	Distro       types.String `tfsdk:"distro"`
 	Distribution types.String `tfsdk:"distribution"`
 	Version      types.String `tfsdk:"version"`
 	Image        types.String `tfsdk:"image"`
+	Architecture types.String `tfsdk:"architecture"`
 	Cost         types.Int64  `tfsdk:"cost"`
 }
 
@@ -42,6 +53,10 @@ func (*ImagesDataSource) Metadata(_ context.Context, req datasource.MetadataRequ
 func (d *ImagesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"distribution":  schema.StringAttribute{Optional: true, Description: imageDistributionDescription},
+			"version_regex": schema.StringAttribute{Optional: true, Description: imageVersionRegexDescription},
+			"architecture":  schema.StringAttribute{Optional: true, Description: imageArchitectureDescription},
+			"most_recent":   schema.BoolAttribute{Optional: true, Description: "Only return the images with the highest version"},
 			"images": schema.ListNestedAttribute{
 				Computed:    true,
 				Description: "OS images",
@@ -51,6 +66,7 @@ func (d *ImagesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						"distribution": schema.StringAttribute{Computed: true, Description: "distribution"},
 						"version":      schema.StringAttribute{Computed: true, Description: "version"},
 						"image":        schema.StringAttribute{Computed: true, Description: "image"},
+						"architecture": schema.StringAttribute{Computed: true, Description: "architecture"},
 						"cost":         schema.Int64Attribute{Computed: true, Description: "cost"},
 					},
 				},
@@ -80,6 +96,13 @@ func (d *ImagesDataSource) Configure(_ context.Context, req datasource.Configure
 // Read refreshes the Terraform state with the latest data
 func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	tflog.Debug(ctx, "Preparing to read `item` data source")
+	var state ImagesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// get images
 	images, err := d.client.CloudInstances().ListOsImages()
 	if err != nil {
@@ -89,24 +112,107 @@ func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		)
 		return
 	}
+	images, diags = filterImages(images, state.Distribution, state.VersionRegex, state.Architecture, state.MostRecent)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Map response body to model
-	state := ImagesDataSourceModel{}
+	state.Images = []ImageDataSourceModel{}
 	for _, image := range images {
-		resourceState := ImageDataSourceModel{
-			Distro:       types.StringValue(image.Distro),
-			Distribution: types.StringValue(image.Distribution),
-			Version:      types.StringValue(image.Version),
-			Image:        types.StringValue(image.Image),
-			Cost:         types.Int64Value(int64(image.Cost)),
-		}
-		state.Images = append(state.Images, resourceState)
+		state.Images = append(state.Images, imageDataSourceModel(image))
 	}
 
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	tflog.Debug(ctx, "Finished reading `images` data source", map[string]any{"success": true})
 }
+
+const (
+	imageDistributionDescription = "Only return images of the distribution eg: ubuntu"
+	imageVersionRegexDescription = "Only return images whose version matches the regular expression eg: ^22\\."
+	imageArchitectureDescription = "Only return images for the architecture eg: x86_64"
+)
+
+func imageDataSourceModel(image utho.OsImage) ImageDataSourceModel {
+	return ImageDataSourceModel{
+		Distro:       types.StringValue(image.Distro),
+		Distribution: types.StringValue(image.Distribution),
+		Version:      types.StringValue(image.Version),
+		Image:        types.StringValue(image.Image),
+		Architecture: types.StringValue(imageArchitecture(image.Image)),
+		Cost:         types.Int64Value(int64(image.Cost)),
+	}
+}
+
+// filterImages returns the images matching the set filters, in the api order.
+func filterImages(images []utho.OsImage, distribution, versionRegex, architecture types.String, mostRecent types.Bool) ([]utho.OsImage, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var version *regexp.Regexp
+	if !versionRegex.IsNull() {
+		var err error
+		version, err = regexp.Compile(versionRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("version_regex"), "Invalid version_regex", "Could not compile "+versionRegex.ValueString()+": "+err.Error())
+			return nil, diags
+		}
+	}
+
+	filtered := []utho.OsImage{}
+	for _, image := range images {
+		if !distribution.IsNull() && !strings.EqualFold(image.Distribution, distribution.ValueString()) {
+			continue
+		}
+		if version != nil && !version.MatchString(image.Version) {
+			continue
+		}
+		if !architecture.IsNull() && imageArchitecture(image.Image) != architecture.ValueString() {
+			continue
+		}
+		filtered = append(filtered, image)
+	}
+
+	if mostRecent.ValueBool() && len(filtered) > 0 {
+		latest := slices.MaxFunc(filtered, func(a, b utho.OsImage) int { return compareImageVersions(a.Version, b.Version) })
+		filtered = slices.DeleteFunc(filtered, func(image utho.OsImage) bool {
+			return compareImageVersions(image.Version, latest.Version) != 0
+		})
+	}
+	return filtered, diags
+}
+
+// imageArchitecture returns the architecture suffix of an image name eg: ubuntu-22.04-x86_64.
+func imageArchitecture(image string) string {
+	for _, architecture := range []string{"x86_64", "amd64", "aarch64", "arm64", "i386"} {
+		if strings.HasSuffix(image, "-"+architecture) {
+			return architecture
+		}
+	}
+	return ""
+}
+
+// compareImageVersions compares dotted versions part by part, numeric parts by value eg: 22.04 > 20.10 and 9.10 > 9.9.
+func compareImageVersions(a, b string) int {
+	split := func(r rune) bool { return r == '.' || r == '-' || r == ' ' }
+	as, bs := strings.FieldsFunc(a, split), strings.FieldsFunc(b, split)
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		var c int
+		if aErr == nil && bErr == nil {
+			c = an - bn
+		} else {
+			c = strings.Compare(as[i], bs[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return len(as) - len(bs)
+}
@@ -3,7 +3,9 @@ package provider
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/uthoplatforms/utho-go/utho"
 )
 
 func TestAccImagesDataSource(t *testing.T) {
@@ -23,3 +25,88 @@ func TestAccImagesDataSource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccImagesDataSourceFilters(t *testing.T) {
+	resourceName := "data.utho_images.example"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "utho_images" "example" {
+	distribution = "ubuntu"
+	architecture = "x86_64"
+	most_recent  = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "images.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "images.0.architecture", "x86_64"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterImages(t *testing.T) {
+	images := []utho.OsImage{
+		{Distribution: "ubuntu", Version: "20.04", Image: "ubuntu-20.04-x86_64"},
+		{Distribution: "ubuntu", Version: "22.04", Image: "ubuntu-22.04-x86_64"},
+		{Distribution: "ubuntu", Version: "22.04", Image: "ubuntu-22.04-arm64"},
+		{Distribution: "centos", Version: "7.9", Image: "centos-7.9-x86_64"},
+		{Distribution: "centos", Version: "7.10", Image: "centos-7.10-x86_64"},
+	}
+
+	tests := []struct {
+		name         string
+		distribution types.String
+		versionRegex types.String
+		architecture types.String
+		mostRecent   types.Bool
+		want         []string
+	}{
+		{
+			name:         "distribution",
+			distribution: types.StringValue("CentOS"),
+			want:         []string{"centos-7.9-x86_64", "centos-7.10-x86_64"},
+		},
+		{
+			name:         "most recent compares versions by value",
+			distribution: types.StringValue("centos"),
+			mostRecent:   types.BoolValue(true),
+			want:         []string{"centos-7.10-x86_64"},
+		},
+		{
+			name:         "most recent keeps every architecture",
+			distribution: types.StringValue("ubuntu"),
+			mostRecent:   types.BoolValue(true),
+			want:         []string{"ubuntu-22.04-x86_64", "ubuntu-22.04-arm64"},
+		},
+		{
+			name:         "version regex and architecture",
+			versionRegex: types.StringValue(`^2\d\.`),
+			architecture: types.StringValue("x86_64"),
+			want:         []string{"ubuntu-20.04-x86_64", "ubuntu-22.04-x86_64"},
+		},
+	}
+
+	for _, test := range tests {
+		filtered, diags := filterImages(images, test.distribution, test.versionRegex, test.architecture, test.mostRecent)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", test.name, diags)
+		}
+		var got []string
+		for _, image := range filtered {
+			got = append(got, image.Image)
+		}
+		if len(got) != len(test.want) {
+			t.Fatalf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+			}
+		}
+	}
+}
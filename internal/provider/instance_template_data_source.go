@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+var (
+	_ datasource.DataSource                     = &InstanceTemplateDataSource{}
+	_ datasource.DataSourceWithConfigure        = &InstanceTemplateDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &InstanceTemplateDataSource{}
+)
+
+type InstanceTemplateDataSource struct {
+	client utho.Client
+}
+
+type InstanceTemplateDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Image       types.String `tfsdk:"image"`
+	UserData    types.String `tfsdk:"user_data"`
+	Status      types.String `tfsdk:"status"`
+}
+
+func NewInstanceTemplateDataSource() datasource.DataSource {
+	return &InstanceTemplateDataSource{}
+}
+
+func (*InstanceTemplateDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_template"
+}
+
+// Schema defines the schema for the data source.
+func (d *InstanceTemplateDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Optional: true, Computed: true, Description: "Template id. Conflicts with name"},
+			"name":        schema.StringAttribute{Optional: true, Computed: true, Description: "Template name. Conflicts with id"},
+			"description": schema.StringAttribute{Computed: true, Description: "Template description"},
+			"image":       schema.StringAttribute{Computed: true, Description: "Image instances are launched from"},
+			"user_data":   schema.StringAttribute{Computed: true, Description: "Script run by cloud-init on the first boot of the launched instances"},
+			"status":      schema.StringAttribute{Computed: true, Description: "status"},
+		},
+	}
+}
+
+// ConfigValidators requires the template to be looked up either by id or by name.
+func (d *InstanceTemplateDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *InstanceTemplateDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(utho.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected InstanceTemplate Data Source Configure Type",
+			fmt.Sprintf("Expected utho.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *InstanceTemplateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read `instance_template` data source")
+	var config InstanceTemplateDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get instance templates
+	stacks, err := d.client.Stacks().List()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list `instance_template`",
+			err.Error(),
+		)
+		return
+	}
+
+	var matches []utho.Stack
+	for _, stack := range stacks {
+		if (!config.ID.IsNull() && stack.ID == config.ID.ValueString()) ||
+			(!config.Name.IsNull() && stack.Title == config.Name.ValueString()) {
+			matches = append(matches, stack)
+		}
+	}
+	if len(matches) != 1 {
+		resp.Diagnostics.AddError(
+			"Unable to find `instance_template`",
+			fmt.Sprintf("Expected exactly one instance template matching id %q or name %q, found %d", config.ID.ValueString(), config.Name.ValueString(), len(matches)),
+		)
+		return
+	}
+	stack := matches[0]
+
+	// Map response body to model
+	image := ""
+	if len(stack.Distro) > 0 {
+		image = stack.Distro[0]
+	}
+	state := InstanceTemplateDataSourceModel{
+		ID:          types.StringValue(stack.ID),
+		Name:        types.StringValue(stack.Title),
+		Description: types.StringValue(stack.Description),
+		Image:       types.StringValue(image),
+		UserData:    types.StringValue(stack.Script),
+		Status:      types.StringValue(stack.Status),
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Finished reading `instance_template` data source", map[string]any{"success": true})
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccInstanceTemplateDataSource(t *testing.T) {
+	resourceName := "data.utho_instance_template.example"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "utho_instance_template" "example" {
+	name         = "example-template"
+	image        = "ubuntu-22.04-x86_64"
+	planid       = "10045"
+	os_disk_size = 80
+}
+
+data "utho_instance_template" "example" {
+	name = utho_instance_template.example.name
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", "utho_instance_template.example", "id"),
+					resource.TestCheckResourceAttr(resourceName, "name", "example-template"),
+					resource.TestCheckResourceAttrSet(resourceName, "image"),
+				),
+			},
+		},
+	})
+}
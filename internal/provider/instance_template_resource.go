@@ -0,0 +1,383 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// implement resource interfaces.
+var (
+	_ resource.Resource                   = &InstanceTemplateResource{}
+	_ resource.ResourceWithConfigure      = &InstanceTemplateResource{}
+	_ resource.ResourceWithValidateConfig = &InstanceTemplateResource{}
+	_ resource.ResourceWithImportState    = &InstanceTemplateResource{}
+)
+
+// NewInstanceTemplateResource is a helper function to simplify the provider implementation.
+func NewInstanceTemplateResource() resource.Resource {
+	return &InstanceTemplateResource{}
+}
+
+// InstanceTemplateResource is the resource implementation.
+// Templates are stored as utho stacks, the stack holds the image and the boot script
+// while the remaining launch settings are kept in the terraform state for autoscaling groups to reference.
+type InstanceTemplateResource struct {
+	client utho.Client
+}
+
+type InstanceTemplateResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Image       types.String `tfsdk:"image"`
+	Planid      types.String `tfsdk:"planid"`
+	OsDiskSize  types.Int64  `tfsdk:"os_disk_size"`
+	SshKeys     types.List   `tfsdk:"ssh_keys"`
+	UserData    types.String `tfsdk:"user_data"`
+	FirewallID  types.String `tfsdk:"firewall_id"`
+	VpcID       types.String `tfsdk:"vpc_id"`
+	Status      types.String `tfsdk:"status"`
+}
+
+// Metadata returns the resource type name.
+func (s *InstanceTemplateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_template"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *InstanceTemplateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(utho.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected InstanceTemplate Data Source Configure Type",
+			fmt.Sprintf("Expected utho.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// Schema defines the schema for the resource.
+func (s *InstanceTemplateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Launch template for autoscaling groups, it holds the image and boot script of the launched instances. " +
+			"Changing the image, ssh_keys or user_data creates a new template: a group using it as stackid replaces its instances in batches " +
+			"when instance_refresh is configured and is replaced otherwise. The plan, disk size, firewall and vpc are not stored by utho, " +
+			"they are kept in the state for the group to reference and change in place, a group using them is replaced when they change.",
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true, Description: "id, use it as the stackid of an autoscaling group", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"name":        schema.StringAttribute{Required: true, Description: "Template name"},
+			"description": schema.StringAttribute{Optional: true, Description: "Template description"},
+			"image": schema.StringAttribute{Required: true, Description: "Image instances are launched from eg: ubuntu-22.04-x86_64",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"planid": schema.StringAttribute{Required: true, Description: "Plan of the launched instances eg: 10045, use it as the planid of an autoscaling group"},
+			"os_disk_size": schema.Int64Attribute{Required: true, Description: "OS disk size in GB, use it as the os_disk_size of an autoscaling group",
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"ssh_keys": schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Public ssh keys authorized for root on the launched instances",
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()},
+			},
+			"user_data": schema.StringAttribute{Optional: true, Description: "Script run by cloud-init on the first boot of the launched instances, raw or base64 encoded. With ssh_keys it must be a shell script, the keys are installed at its start",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"firewall_id": schema.StringAttribute{Optional: true, Description: "Firewall attached to the launched instances, use it as the security_group_id of an autoscaling group"},
+			"vpc_id":      schema.StringAttribute{Optional: true, Description: "VPC of the launched instances, use it as the vpc_id of an autoscaling group"},
+			"status":      schema.StringAttribute{Computed: true, Description: "status"},
+		},
+	}
+}
+
+// ValidateConfig checks that user data combined with ssh_keys is a shell script.
+func (s *InstanceTemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var sshKeys types.List
+	var userData types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ssh_keys"), &sshKeys)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("user_data"), &userData)...)
+	if resp.Diagnostics.HasError() || sshKeys.IsNull() || len(sshKeys.Elements()) == 0 || userData.IsNull() || userData.IsUnknown() {
+		return
+	}
+
+	if script := string(decodeUserData(userData.ValueString())); script != "" {
+		if _, ok := shellScriptShebang(script); !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("user_data"),
+				"Invalid user_data",
+				"With ssh_keys the user data must be a shell script starting with a #!/bin/sh or #!/bin/bash line, the keys are installed at the start of the script. "+
+					"Cloud-config and other formats can not be combined with ssh_keys, add the keys to the cloud-config instead",
+			)
+		}
+	}
+}
+
+// Import using template id as the attribute
+func (s *InstanceTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Create a new resource.
+func (s *InstanceTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "create instance template")
+	// Retrieve values from plan
+	var plan InstanceTemplateResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sshKeys := []string{}
+	diags = plan.SshKeys.ElementsAs(ctx, &sshKeys, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	stackRequest := utho.CreateStacksParams{
+		Title:       plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+		Images:      plan.Image.ValueString(),
+		Status:      "1",
+		IsPublic:    "0",
+		Script:      instanceTemplateScript(sshKeys, plan.UserData.ValueString()),
+	}
+	tflog.Debug(ctx, "send create instance template request")
+	stackResp, err := s.client.Stacks().Create(stackRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating instance template",
+			"Could not create instance template, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	stack, err := s.client.Stacks().Read(stackResp.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho instance template",
+			"Could not read utho instance template "+stackResp.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = types.StringValue(stack.ID)
+	plan.Status = types.StringValue(stack.Status)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish create instance template")
+}
+
+// Read resource information.
+func (s *InstanceTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "read instance template")
+
+	// Get current state
+	var state InstanceTemplateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "send get instance template request")
+	// Get refreshed template value from utho
+	stack, err := s.client.Stacks().Read(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho instance template",
+			"Could not read utho instance template "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Overwrite items with refreshed state, imported templates read the image and split the script into ssh keys and user data
+	state.Name = types.StringValue(stack.Title)
+	if state.Image.IsNull() && len(stack.Distro) > 0 {
+		state.Image = types.StringValue(stack.Distro[0])
+	}
+	if state.UserData.IsNull() && state.SshKeys.IsNull() && stack.Script != "" {
+		sshKeys, userData := parseInstanceTemplateScript(stack.Script)
+		if len(sshKeys) > 0 {
+			state.SshKeys, diags = types.ListValueFrom(ctx, types.StringType, sshKeys)
+			resp.Diagnostics.Append(diags...)
+		}
+		if userData != "" {
+			state.UserData = types.StringValue(userData)
+		}
+	}
+	if !state.Description.IsNull() || stack.Description != "" {
+		state.Description = types.StringValue(stack.Description)
+	}
+	state.Status = types.StringValue(stack.Status)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish get instance template request")
+}
+
+func (s *InstanceTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "update instance template")
+	var plan InstanceTemplateResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sshKeys := []string{}
+	diags = plan.SshKeys.ElementsAs(ctx, &sshKeys, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the name and description change on the stack, the launch settings kept in the state are taken from the plan
+	tflog.Debug(ctx, "send update instance template request")
+	_, err := s.client.Stacks().Update(utho.UpdateStacksParams{
+		StackId:     plan.ID.ValueString(),
+		Title:       plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+		Images:      plan.Image.ValueString(),
+		Status:      "1",
+		IsPublic:    "0",
+		Script:      instanceTemplateScript(sshKeys, plan.UserData.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating utho instance template",
+			"Could not update utho instance template "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	stack, err := s.client.Stacks().Read(plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho instance template",
+			"Could not read utho instance template "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	plan.Status = types.StringValue(stack.Status)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish update instance template")
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (s *InstanceTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "delete instance template")
+	// Get current state
+	var state InstanceTemplateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "send delete instance template request")
+	// delete instance template
+	_, err := s.client.Stacks().Delete(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleteing utho instance template",
+			"Could not delete utho instance template "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// instanceTemplateScript builds the stack script, authorizing the ssh keys before running the user data.
+// With ssh keys the user data must be a shell script, see shellScriptShebang.
+func instanceTemplateScript(sshKeys []string, userData string) string {
+	if len(sshKeys) == 0 {
+		return string(decodeUserData(userData))
+	}
+
+	// the key install script runs under the shebang of the user data
+	shebang, body := "#!/bin/bash", ""
+	if script := string(decodeUserData(userData)); script != "" {
+		shebang, _ = shellScriptShebang(script)
+		_, body, _ = strings.Cut(script, "\n")
+	}
+
+	var script strings.Builder
+	script.WriteString(shebang + "\n")
+	script.WriteString("mkdir -p /root/.ssh && chmod 700 /root/.ssh\n")
+	script.WriteString("cat >> /root/.ssh/authorized_keys <<'UTHO_SSH_KEYS'\n")
+	for _, key := range sshKeys {
+		script.WriteString(strings.TrimSpace(key) + "\n")
+	}
+	script.WriteString("UTHO_SSH_KEYS\n")
+	script.WriteString("chmod 600 /root/.ssh/authorized_keys\n")
+	script.WriteString(body)
+
+	return script.String()
+}
+
+// shellScriptShebang returns the first line of the script and whether it runs the script with a posix shell.
+func shellScriptShebang(script string) (string, bool) {
+	line, _, _ := strings.Cut(script, "\n")
+	line = strings.TrimSuffix(line, "\r")
+	interpreter := strings.Fields(strings.TrimPrefix(line, "#!"))
+	if !strings.HasPrefix(line, "#!") || len(interpreter) == 0 {
+		return line, false
+	}
+	shell := interpreter[0][strings.LastIndex(interpreter[0], "/")+1:]
+	if shell == "env" && len(interpreter) > 1 {
+		shell = interpreter[1]
+	}
+	return line, slices.Contains([]string{"sh", "bash", "dash"}, shell)
+}
+
+// parseInstanceTemplateScript returns the ssh keys and user data a stack script was built from by instanceTemplateScript.
+func parseInstanceTemplateScript(script string) ([]string, string) {
+	shebang, rest, _ := strings.Cut(script, "\n")
+	header := "mkdir -p /root/.ssh && chmod 700 /root/.ssh\n" +
+		"cat >> /root/.ssh/authorized_keys <<'UTHO_SSH_KEYS'\n"
+	keys, body, found := strings.Cut(strings.TrimPrefix(rest, header), "UTHO_SSH_KEYS\nchmod 600 /root/.ssh/authorized_keys\n")
+	if !strings.HasPrefix(rest, header) || !found {
+		return nil, script
+	}
+
+	sshKeys := strings.Split(strings.TrimSuffix(keys, "\n"), "\n")
+	if body == "" && shebang == "#!/bin/bash" {
+		// the script only installs the keys
+		return sshKeys, ""
+	}
+	return sshKeys, shebang + "\n" + body
+}
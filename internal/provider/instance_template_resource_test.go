@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccInstanceTemplateResource(t *testing.T) {
+	resourceName := "utho_instance_template.example"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "utho_instance_template" "example" {
+	name         = "example-template"
+	image        = "ubuntu-22.04-x86_64"
+	planid       = "10045"
+	os_disk_size = 80
+	user_data    = "#!/bin/bash\napt-get update -y\n"
+	vpc_id       = "4de5f07a-f51c-4323-b39a-ef66130e1bd9"
+}
+
+resource "utho_auto_scaling" "example" {
+	name                = "example-name"
+	os_disk_size        = utho_instance_template.example.os_disk_size
+	dcslug              = "inmumbaizone2"
+	minsize             = 1
+	maxsize             = 2
+	desiredsize         = 1
+	planid              = utho_instance_template.example.planid
+	planname            = "basic"
+	instance_templateid = "none"
+	public_ip_enabled   = "true"
+	stackid             = utho_instance_template.example.id
+	stackimage          = utho_instance_template.example.image
+	vpc_id              = utho_instance_template.example.vpc_id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "example-template"),
+					resource.TestCheckResourceAttr(resourceName, "image", "ubuntu-22.04-x86_64"),
+					resource.TestCheckResourceAttr(resourceName, "planid", "10045"),
+					resource.TestCheckResourceAttr(resourceName, "os_disk_size", "80"),
+					resource.TestCheckResourceAttrPair("utho_auto_scaling.example", "stackid", resourceName, "id"),
+					resource.TestCheckResourceAttrPair("utho_auto_scaling.example", "planid", resourceName, "planid"),
+
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "status"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the launch settings are not stored by utho
+				ImportStateVerifyIgnore: []string{"planid", "os_disk_size", "firewall_id", "vpc_id"},
+			},
+		},
+	})
+}
+
+func TestInstanceTemplateScript(t *testing.T) {
+	if got := instanceTemplateScript(nil, "#cloud-config\npackages: [nginx]\n"); got != "#cloud-config\npackages: [nginx]\n" {
+		t.Errorf("script without ssh keys = %q, want the user data", got)
+	}
+	if got := instanceTemplateScript(nil, "I2Nsb3VkLWNvbmZpZwo="); got != "#cloud-config\n" {
+		t.Errorf("script without ssh keys = %q, want the decoded user data", got)
+	}
+
+	keys := func(shebang string) string {
+		return shebang + "\n" +
+			"mkdir -p /root/.ssh && chmod 700 /root/.ssh\n" +
+			"cat >> /root/.ssh/authorized_keys <<'UTHO_SSH_KEYS'\n" +
+			"ssh-ed25519 AAAA user@example\n" +
+			"UTHO_SSH_KEYS\n" +
+			"chmod 600 /root/.ssh/authorized_keys\n"
+	}
+	for name, tc := range map[string]struct {
+		userData, want string
+	}{
+		"no user data": {userData: "", want: keys("#!/bin/bash")},
+		"bash":         {userData: "#!/bin/bash\necho hi\n", want: keys("#!/bin/bash") + "echo hi\n"},
+		"sh":           {userData: "#!/bin/sh -e\necho hi\n", want: keys("#!/bin/sh -e") + "echo hi\n"},
+		"env bash":     {userData: "#!/usr/bin/env bash\necho hi\n", want: keys("#!/usr/bin/env bash") + "echo hi\n"},
+		"crlf":         {userData: "#!/bin/bash\r\necho hi\r\n", want: keys("#!/bin/bash") + "echo hi\r\n"},
+		"base64":       {userData: "IyEvYmluL2Jhc2gKZWNobyBoaQo=", want: keys("#!/bin/bash") + "echo hi\n"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := instanceTemplateScript([]string{"ssh-ed25519 AAAA user@example\n"}, tc.userData); got != tc.want {
+				t.Errorf("script = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseInstanceTemplateScript(t *testing.T) {
+	for name, tc := range map[string]struct {
+		sshKeys  []string
+		userData string
+	}{
+		"keys only":     {sshKeys: []string{"ssh-ed25519 AAAA user@example"}},
+		"keys and bash": {sshKeys: []string{"ssh-ed25519 AAAA a", "ssh-rsa BBBB b"}, userData: "#!/bin/sh -e\necho hi\n"},
+		"user data":     {userData: "#cloud-config\npackages: [nginx]\n"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sshKeys, userData := parseInstanceTemplateScript(instanceTemplateScript(tc.sshKeys, tc.userData))
+			if !slices.Equal(sshKeys, tc.sshKeys) || userData != tc.userData {
+				t.Errorf("parseInstanceTemplateScript() = %q, %q, want %q, %q", sshKeys, userData, tc.sshKeys, tc.userData)
+			}
+		})
+	}
+}
+
+func TestShellScriptShebang(t *testing.T) {
+	for script, want := range map[string]bool{
+		"#!/bin/bash\necho hi\n":         true,
+		"#!/bin/sh\r\necho hi\r\n":       true,
+		"#!/usr/bin/env bash\necho hi\n": true,
+		"#!/usr/bin/python3\nprint(1)\n": false,
+		"#cloud-config\npackages: []\n":  false,
+		"echo hi\n":                      false,
+		"#!\n":                           false,
+	} {
+		if _, got := shellScriptShebang(script); got != want {
+			t.Errorf("shellScriptShebang(%q) = %t, want %t", script, got, want)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+var (
+	_ datasource.DataSource              = &listDataSource{}
+	_ datasource.DataSourceWithConfigure = &listDataSource{}
+)
+
+// listDataSource lists the objects of a type, the filter and sort blocks are evaluated
+// client side over the list api results.
+type listDataSource struct {
+	client utho.Client
+
+	// typeName is appended to the provider type name.
+	typeName string
+	// attribute holds the listed objects.
+	attribute   string
+	description string
+	// itemAttributes are the attributes of a listed object, filters and sort keys refer to them.
+	itemAttributes map[string]schema.Attribute
+	// list returns the objects as values of the item attributes.
+	list func(client utho.Client) ([]map[string]attr.Value, error)
+}
+
+type listFilterModel struct {
+	Name    types.String   `tfsdk:"name"`
+	Values  []types.String `tfsdk:"values"`
+	MatchBy types.String   `tfsdk:"match_by"`
+}
+
+type listSortModel struct {
+	Key       types.String `tfsdk:"key"`
+	Direction types.String `tfsdk:"direction"`
+}
+
+const (
+	listMatchByExact     = "exact"
+	listMatchByRegex     = "regex"
+	listMatchBySubstring = "substring"
+
+	listSortAsc  = "asc"
+	listSortDesc = "desc"
+)
+
+func (d *listDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + d.typeName
+}
+
+// Schema defines the schema for the data source.
+func (d *listDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			d.attribute: schema.ListNestedAttribute{
+				Computed:     true,
+				Description:  d.description,
+				NestedObject: schema.NestedAttributeObject{Attributes: d.itemAttributes},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": listFilterBlock(d.itemAttributes),
+			"sort":   listSortBlock(d.itemAttributes),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *listDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(utho.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected utho.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *listDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read `"+d.attribute+"` data source")
+	var filters []listFilterModel
+	var sorts []listSortModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("filter"), &filters)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sort"), &sorts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get objects
+	items, err := d.list(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list `"+d.attribute+"`",
+			err.Error(),
+		)
+		return
+	}
+
+	items, diags := filterListItems(items, filters)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sortListItems(items, sorts)
+
+	// Map items to objects
+	itemTypes := make(map[string]attr.Type, len(d.itemAttributes))
+	for name, attribute := range d.itemAttributes {
+		itemTypes[name] = attribute.GetType()
+	}
+	objects := make([]attr.Value, 0, len(items))
+	for _, item := range items {
+		object, diags := types.ObjectValue(itemTypes, item)
+		resp.Diagnostics.Append(diags...)
+		objects = append(objects, object)
+	}
+	list, diags := types.ListValue(types.ObjectType{AttrTypes: itemTypes}, objects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	resp.State.Raw = req.Config.Raw
+	diags = resp.State.SetAttribute(ctx, path.Root(d.attribute), list)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Finished reading `"+d.attribute+"` data source", map[string]any{"success": true})
+}
+
+// listFilterBlock returns the filter block for objects with the given attributes.
+func listFilterBlock(itemAttributes map[string]schema.Attribute) schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description: "Only keep the objects matching the filter. Objects must match every filter block",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{Required: true, Description: "Attribute to filter on",
+					Validators: []validator.String{stringvalidator.OneOf(listItemAttributeNames(itemAttributes)...)},
+				},
+				"values": schema.ListAttribute{Required: true, ElementType: types.StringType, Description: "The attribute must match one of the values"},
+				"match_by": schema.StringAttribute{Optional: true, Description: "How values are matched: exact, regex or substring. Defaults to exact",
+					Validators: []validator.String{stringvalidator.OneOf(listMatchByExact, listMatchByRegex, listMatchBySubstring)},
+				},
+			},
+		},
+	}
+}
+
+// listSortBlock returns the sort block for objects with the given attributes.
+func listSortBlock(itemAttributes map[string]schema.Attribute) schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description: "Sort the objects by the attribute. Later sort blocks order objects with equal keys",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"key": schema.StringAttribute{Required: true, Description: "Attribute to sort by",
+					Validators: []validator.String{stringvalidator.OneOf(listItemAttributeNames(itemAttributes)...)},
+				},
+				"direction": schema.StringAttribute{Optional: true, Description: "Sort direction: asc or desc. Defaults to asc",
+					Validators: []validator.String{stringvalidator.OneOf(listSortAsc, listSortDesc)},
+				},
+			},
+		},
+	}
+}
+
+func listItemAttributeNames(itemAttributes map[string]schema.Attribute) []string {
+	names := make([]string, 0, len(itemAttributes))
+	for name := range itemAttributes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// filterListItems returns the items matching every filter.
+func filterListItems(items []map[string]attr.Value, filters []listFilterModel) ([]map[string]attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	matchers := make([]func(map[string]attr.Value) bool, 0, len(filters))
+	for _, filter := range filters {
+		name := filter.Name.ValueString()
+		values := make([]string, 0, len(filter.Values))
+		for _, value := range filter.Values {
+			values = append(values, value.ValueString())
+		}
+
+		var match func(string) bool
+		switch filter.MatchBy.ValueString() {
+		case listMatchByRegex:
+			patterns := make([]*regexp.Regexp, 0, len(values))
+			for _, value := range values {
+				pattern, err := regexp.Compile(value)
+				if err != nil {
+					diags.AddAttributeError(path.Root("filter"), "Invalid filter regex", "Could not compile "+value+": "+err.Error())
+					continue
+				}
+				patterns = append(patterns, pattern)
+			}
+			match = func(s string) bool {
+				return slices.ContainsFunc(patterns, func(pattern *regexp.Regexp) bool { return pattern.MatchString(s) })
+			}
+		case listMatchBySubstring:
+			match = func(s string) bool {
+				return slices.ContainsFunc(values, func(value string) bool { return strings.Contains(s, value) })
+			}
+		default:
+			match = func(s string) bool { return slices.Contains(values, s) }
+		}
+		matchers = append(matchers, func(item map[string]attr.Value) bool {
+			return match(listItemString(item[name]))
+		})
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	filtered := []map[string]attr.Value{}
+	for _, item := range items {
+		if !slices.ContainsFunc(matchers, func(matches func(map[string]attr.Value) bool) bool { return !matches(item) }) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, diags
+}
+
+// sortListItems sorts the items by the sort keys in order, numbers are compared by value.
+func sortListItems(items []map[string]attr.Value, sorts []listSortModel) {
+	if len(sorts) == 0 {
+		return
+	}
+
+	slices.SortStableFunc(items, func(a, b map[string]attr.Value) int {
+		for _, sort := range sorts {
+			key := sort.Key.ValueString()
+			c := compareListItemValues(a[key], b[key])
+			if sort.Direction.ValueString() == listSortDesc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+func compareListItemValues(a, b attr.Value) int {
+	switch a := a.(type) {
+	case types.Int64:
+		if b, ok := b.(types.Int64); ok {
+			return cmp.Compare(a.ValueInt64(), b.ValueInt64())
+		}
+	case types.Float64:
+		if b, ok := b.(types.Float64); ok {
+			return cmp.Compare(a.ValueFloat64(), b.ValueFloat64())
+		}
+	}
+	return cmp.Compare(listItemString(a), listItemString(b))
+}
+
+// listItemString returns the value as it is written in the configuration, for matching filter values.
+func listItemString(value attr.Value) string {
+	switch v := value.(type) {
+	case types.String:
+		return v.ValueString()
+	case types.Int64:
+		return strconv.FormatInt(v.ValueInt64(), 10)
+	case types.Float64:
+		return strconv.FormatFloat(v.ValueFloat64(), 'f', -1, 64)
+	case types.Bool:
+		return strconv.FormatBool(v.ValueBool())
+	}
+	return ""
+}
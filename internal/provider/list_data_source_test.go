@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testListItems() []map[string]attr.Value {
+	return []map[string]attr.Value{
+		{"name": types.StringValue("web-2"), "dcslug": types.StringValue("innoida"), "disksize": types.Int64Value(80)},
+		{"name": types.StringValue("db-1"), "dcslug": types.StringValue("inmumbaizone2"), "disksize": types.Int64Value(160)},
+		{"name": types.StringValue("web-1"), "dcslug": types.StringValue("innoida"), "disksize": types.Int64Value(80)},
+	}
+}
+
+func listItemNames(items []map[string]attr.Value) []string {
+	var names []string
+	for _, item := range items {
+		names = append(names, listItemString(item["name"]))
+	}
+	return names
+}
+
+func TestFilterListItems(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters []listFilterModel
+		want    []string
+	}{
+		{
+			name: "exact",
+			filters: []listFilterModel{
+				{Name: types.StringValue("dcslug"), Values: []types.String{types.StringValue("innoida")}},
+			},
+			want: []string{"web-2", "web-1"},
+		},
+		{
+			name: "regex",
+			filters: []listFilterModel{
+				{Name: types.StringValue("name"), Values: []types.String{types.StringValue("^db-")}, MatchBy: types.StringValue("regex")},
+			},
+			want: []string{"db-1"},
+		},
+		{
+			name: "substring and number",
+			filters: []listFilterModel{
+				{Name: types.StringValue("name"), Values: []types.String{types.StringValue("-1")}, MatchBy: types.StringValue("substring")},
+				{Name: types.StringValue("disksize"), Values: []types.String{types.StringValue("80")}},
+			},
+			want: []string{"web-1"},
+		},
+		{
+			name: "any value",
+			filters: []listFilterModel{
+				{Name: types.StringValue("name"), Values: []types.String{types.StringValue("web-1"), types.StringValue("db-1")}},
+			},
+			want: []string{"db-1", "web-1"},
+		},
+	}
+
+	for _, test := range tests {
+		items, diags := filterListItems(testListItems(), test.filters)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", test.name, diags)
+		}
+		got := listItemNames(items)
+		if len(got) != len(test.want) {
+			t.Fatalf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+			}
+		}
+	}
+
+	_, diags := filterListItems(testListItems(), []listFilterModel{
+		{Name: types.StringValue("name"), Values: []types.String{types.StringValue("(")}, MatchBy: types.StringValue("regex")},
+	})
+	if !diags.HasError() {
+		t.Error("expected an error for an invalid regex")
+	}
+}
+
+func TestSortListItems(t *testing.T) {
+	items := testListItems()
+	sortListItems(items, []listSortModel{
+		{Key: types.StringValue("disksize"), Direction: types.StringValue("desc")},
+		{Key: types.StringValue("name")},
+	})
+
+	want := []string{"db-1", "web-1", "web-2"}
+	got := listItemNames(items)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// NewLoadbalancerDataSource is a helper function to simplify the provider implementation.
+// The load balancer is looked up by id or by name.
+func NewLoadbalancerDataSource() datasource.DataSource {
+	return &resourceDataSource{
+		resource:    NewLoadbalancerResource,
+		idAttribute: "id",
+		lookup:      lookupLoadbalancer,
+		dcslug:      false,
+	}
+}
+
+func lookupLoadbalancer(client utho.Client, name, _ string) ([]string, error) {
+	loadbalancers, err := client.Loadbalancers().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, loadbalancer := range loadbalancers {
+		if loadbalancer.Name == name {
+			ids = append(ids, loadbalancer.ID)
+		}
+	}
+	return ids, nil
+}
@@ -19,6 +19,7 @@ var (
 	_ resource.Resource                = &LoadbalancerResource{}
 	_ resource.ResourceWithConfigure   = &LoadbalancerResource{}
 	_ resource.ResourceWithImportState = &LoadbalancerResource{}
+	_ resource.ResourceWithModifyPlan  = &LoadbalancerResource{}
 )
 
 // NewLoadbalancerResource is a helper function to simplify the provider implementation.
@@ -34,26 +35,28 @@ type (
 
 	// LoadbalancerResourceModel is the model implementation.
 	LoadbalancerResourceModel struct {
-		ID             types.String `tfsdk:"id"`
-		Type           types.String `tfsdk:"type"`
-		Dcslug         types.String `tfsdk:"dcslug"`
-		VpcID          types.String `tfsdk:"vpc_id"`
-		EnablePublicip types.String `tfsdk:"enable_publicip"`
-		Firewall       types.String `tfsdk:"firewall"`
-		Cpumodel       types.String `tfsdk:"cpu_model"`
-		Userid         types.String `tfsdk:"userid"`
-		IP             types.String `tfsdk:"ip"`
-		Name           types.String `tfsdk:"name"`
-		Algorithm      types.String `tfsdk:"algorithm"`
-		Cookie         types.String `tfsdk:"cookie"`
-		Cookiename     types.String `tfsdk:"cookiename"`
-		Redirecthttps  types.String `tfsdk:"redirecthttps"`
-		Country        types.String `tfsdk:"country"`
-		Cc             types.String `tfsdk:"cc"`
-		City           types.String `tfsdk:"city"`
-		Backendcount   types.String `tfsdk:"backendcount"`
-		CreatedAt      types.String `tfsdk:"created_at"`
-		Status         types.String `tfsdk:"status"`
+		ID                 types.String `tfsdk:"id"`
+		Type               types.String `tfsdk:"type"`
+		Dcslug             types.String `tfsdk:"dcslug"`
+		VpcID              types.String `tfsdk:"vpc_id"`
+		SubnetID           types.String `tfsdk:"subnet_id"`
+		EnablePublicip     types.String `tfsdk:"enable_publicip"`
+		Firewall           types.String `tfsdk:"firewall"`
+		Cpumodel           types.String `tfsdk:"cpu_model"`
+		Userid             types.String `tfsdk:"userid"`
+		IP                 types.String `tfsdk:"ip"`
+		Name               types.String `tfsdk:"name"`
+		Algorithm          types.String `tfsdk:"algorithm"`
+		Cookie             types.String `tfsdk:"cookie"`
+		Cookiename         types.String `tfsdk:"cookiename"`
+		Redirecthttps      types.String `tfsdk:"redirecthttps"`
+		Country            types.String `tfsdk:"country"`
+		Cc                 types.String `tfsdk:"cc"`
+		City               types.String `tfsdk:"city"`
+		Backendcount       types.String `tfsdk:"backendcount"`
+		CreatedAt          types.String `tfsdk:"created_at"`
+		Status             types.String `tfsdk:"status"`
+		DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 	}
 
 	RuleResourceModel struct {
@@ -123,6 +126,13 @@ func (s *LoadbalancerResource) Schema(_ context.Context, _ resource.SchemaReques
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"subnet_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Id of a subnet of the VPC to place the load balancer in",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"enable_publicip": schema.StringAttribute{
 				Optional:    true,
 				Description: "Enable Public ip",
@@ -144,23 +154,30 @@ func (s *LoadbalancerResource) Schema(_ context.Context, _ resource.SchemaReques
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"id":            schema.StringAttribute{Computed: true, Description: "Id"},
-			"userid":        schema.StringAttribute{Computed: true, Description: "User id"},
-			"ip":            schema.StringAttribute{Computed: true, Description: "Ip"},
-			"algorithm":     schema.StringAttribute{Computed: true, Description: "Algorithm"},
-			"cookie":        schema.StringAttribute{Computed: true, Description: "Cookie"},
-			"cookiename":    schema.StringAttribute{Computed: true, Description: "Cookie name"},
-			"redirecthttps": schema.StringAttribute{Computed: true, Description: "Redirect https"},
-			"country":       schema.StringAttribute{Computed: true, Description: "Country"},
-			"cc":            schema.StringAttribute{Computed: true, Description: "Cc"},
-			"city":          schema.StringAttribute{Computed: true, Description: "City"},
-			"backendcount":  schema.StringAttribute{Computed: true, Description: "Backend count"},
-			"created_at":    schema.StringAttribute{Computed: true, Description: "Created At"},
-			"status":        schema.StringAttribute{Computed: true, Description: "Status"},
+			"id":                  schema.StringAttribute{Computed: true, Description: "Id"},
+			"deletion_protection": deletionProtectionAttribute("loadbalancer"),
+			"userid":              schema.StringAttribute{Computed: true, Description: "User id"},
+			"ip":                  schema.StringAttribute{Computed: true, Description: "Ip"},
+			"algorithm":           schema.StringAttribute{Computed: true, Description: "Algorithm"},
+			"cookie":              schema.StringAttribute{Computed: true, Description: "Cookie"},
+			"cookiename":          schema.StringAttribute{Computed: true, Description: "Cookie name"},
+			"redirecthttps":       schema.StringAttribute{Computed: true, Description: "Redirect https"},
+			"country":             schema.StringAttribute{Computed: true, Description: "Country"},
+			"cc":                  schema.StringAttribute{Computed: true, Description: "Cc"},
+			"city":                schema.StringAttribute{Computed: true, Description: "City"},
+			"backendcount":        schema.StringAttribute{Computed: true, Description: "Backend count"},
+			"created_at":          schema.StringAttribute{Computed: true, Description: "Created At"},
+			"status":              schema.StringAttribute{Computed: true, Description: "Status"},
 		},
 	}
 }
 
+// ModifyPlan validates the planned values against the utho api.
+func (s *LoadbalancerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkDeletionProtectionPlan(ctx, req, resp, "loadbalancer", "id")
+	validateDcslugPlan(ctx, s.client, req, resp)
+}
+
 // Import using id as the attribute
 func (s *LoadbalancerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
@@ -178,7 +195,7 @@ func (s *LoadbalancerResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	// Generate API request body from plan
-	loadbalancerRequest := utho.CreateLoadblancerParams{
+	loadbalancerRequest := createLoadbalancerParams{CreateLoadblancerParams: utho.CreateLoadblancerParams{
 		Dcslug:         plan.Dcslug.ValueString(),
 		Type:           plan.Type.ValueString(),
 		Name:           plan.Name.ValueString(),
@@ -186,7 +203,7 @@ func (s *LoadbalancerResource) Create(ctx context.Context, req resource.CreateRe
 		EnablePublicip: plan.EnablePublicip.ValueString(),
 		Firewall:       plan.Firewall.ValueString(),
 		Cpumodel:       plan.Cpumodel.ValueString(),
-	}
+	}, Subnet: plan.SubnetID.ValueString()}
 	tflog.Debug(ctx, "send create loadbalancer request")
 	tflog.Debug(ctx, "loadbalancerRequest",
 		map[string]interface{}{
@@ -194,13 +211,14 @@ func (s *LoadbalancerResource) Create(ctx context.Context, req resource.CreateRe
 			"type":            loadbalancerRequest.Type,
 			"name":            loadbalancerRequest.Name,
 			"vpc_id":          loadbalancerRequest.VpcID,
+			"subnet_id":       loadbalancerRequest.Subnet,
 			"enable_publicip": loadbalancerRequest.EnablePublicip,
 			"firewall":        loadbalancerRequest.Firewall,
 			"cpu_model":       loadbalancerRequest.Cpumodel,
 		},
 	)
 
-	createloadbalancer, err := s.client.Loadbalancers().Create(loadbalancerRequest)
+	createloadbalancer, err := createLoadbalancer(s.client, loadbalancerRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating loadbalancer",
@@ -219,26 +237,28 @@ func (s *LoadbalancerResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	plan = LoadbalancerResourceModel{
-		ID:             types.StringValue(loadbalancer.ID),
-		Type:           types.StringValue(loadbalancer.Type),
-		Dcslug:         types.StringValue(plan.Dcslug.ValueString()),
-		VpcID:          types.StringValue(plan.VpcID.ValueString()),
-		EnablePublicip: types.StringValue(plan.EnablePublicip.ValueString()),
-		Firewall:       types.StringValue(plan.Firewall.ValueString()),
-		Cpumodel:       types.StringValue(plan.Cpumodel.ValueString()),
-		Userid:         types.StringValue(loadbalancer.Userid),
-		IP:             types.StringValue(loadbalancer.IP),
-		Name:           types.StringValue(loadbalancer.Name),
-		Algorithm:      types.StringValue(loadbalancer.Algorithm),
-		Cookie:         types.StringValue(loadbalancer.Cookie),
-		Cookiename:     types.StringValue(loadbalancer.Cookiename),
-		Redirecthttps:  types.StringValue(loadbalancer.Redirecthttps),
-		Country:        types.StringValue(loadbalancer.Country),
-		Cc:             types.StringValue(loadbalancer.Cc),
-		City:           types.StringValue(loadbalancer.City),
-		Backendcount:   types.StringValue(loadbalancer.Backendcount),
-		CreatedAt:      types.StringValue(loadbalancer.CreatedAt),
-		Status:         types.StringValue(loadbalancer.Status),
+		ID:                 types.StringValue(loadbalancer.ID),
+		DeletionProtection: plan.DeletionProtection,
+		Type:               types.StringValue(loadbalancer.Type),
+		Dcslug:             types.StringValue(plan.Dcslug.ValueString()),
+		VpcID:              types.StringValue(plan.VpcID.ValueString()),
+		SubnetID:           plan.SubnetID,
+		EnablePublicip:     types.StringValue(plan.EnablePublicip.ValueString()),
+		Firewall:           types.StringValue(plan.Firewall.ValueString()),
+		Cpumodel:           types.StringValue(plan.Cpumodel.ValueString()),
+		Userid:             types.StringValue(loadbalancer.Userid),
+		IP:                 types.StringValue(loadbalancer.IP),
+		Name:               types.StringValue(loadbalancer.Name),
+		Algorithm:          types.StringValue(loadbalancer.Algorithm),
+		Cookie:             types.StringValue(loadbalancer.Cookie),
+		Cookiename:         types.StringValue(loadbalancer.Cookiename),
+		Redirecthttps:      types.StringValue(loadbalancer.Redirecthttps),
+		Country:            types.StringValue(loadbalancer.Country),
+		Cc:                 types.StringValue(loadbalancer.Cc),
+		City:               types.StringValue(loadbalancer.City),
+		Backendcount:       types.StringValue(loadbalancer.Backendcount),
+		CreatedAt:          types.StringValue(loadbalancer.CreatedAt),
+		Status:             types.StringValue(loadbalancer.Status),
 	}
 
 	// Set state to fully populated data
@@ -274,26 +294,28 @@ func (s *LoadbalancerResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	state = LoadbalancerResourceModel{
-		ID:             types.StringValue(loadbalancer.ID),
-		Type:           types.StringValue(loadbalancer.Type),
-		Dcslug:         types.StringValue(state.Dcslug.ValueString()),
-		VpcID:          types.StringValue(state.VpcID.ValueString()),
-		EnablePublicip: types.StringValue(state.EnablePublicip.ValueString()),
-		Firewall:       types.StringValue(state.Firewall.ValueString()),
-		Cpumodel:       types.StringValue(state.Cpumodel.ValueString()),
-		Userid:         types.StringValue(loadbalancer.Userid),
-		IP:             types.StringValue(loadbalancer.IP),
-		Name:           types.StringValue(loadbalancer.Name),
-		Algorithm:      types.StringValue(loadbalancer.Algorithm),
-		Cookie:         types.StringValue(loadbalancer.Cookie),
-		Cookiename:     types.StringValue(loadbalancer.Cookiename),
-		Redirecthttps:  types.StringValue(loadbalancer.Redirecthttps),
-		Country:        types.StringValue(loadbalancer.Country),
-		Cc:             types.StringValue(loadbalancer.Cc),
-		City:           types.StringValue(loadbalancer.City),
-		Backendcount:   types.StringValue(loadbalancer.Backendcount),
-		CreatedAt:      types.StringValue(loadbalancer.CreatedAt),
-		Status:         types.StringValue(loadbalancer.Status),
+		ID:                 types.StringValue(loadbalancer.ID),
+		DeletionProtection: state.DeletionProtection,
+		Type:               types.StringValue(loadbalancer.Type),
+		Dcslug:             types.StringValue(state.Dcslug.ValueString()),
+		VpcID:              types.StringValue(state.VpcID.ValueString()),
+		SubnetID:           state.SubnetID,
+		EnablePublicip:     types.StringValue(state.EnablePublicip.ValueString()),
+		Firewall:           types.StringValue(state.Firewall.ValueString()),
+		Cpumodel:           types.StringValue(state.Cpumodel.ValueString()),
+		Userid:             types.StringValue(loadbalancer.Userid),
+		IP:                 types.StringValue(loadbalancer.IP),
+		Name:               types.StringValue(loadbalancer.Name),
+		Algorithm:          types.StringValue(loadbalancer.Algorithm),
+		Cookie:             types.StringValue(loadbalancer.Cookie),
+		Cookiename:         types.StringValue(loadbalancer.Cookiename),
+		Redirecthttps:      types.StringValue(loadbalancer.Redirecthttps),
+		Country:            types.StringValue(loadbalancer.Country),
+		Cc:                 types.StringValue(loadbalancer.Cc),
+		City:               types.StringValue(loadbalancer.City),
+		Backendcount:       types.StringValue(loadbalancer.Backendcount),
+		CreatedAt:          types.StringValue(loadbalancer.CreatedAt),
+		Status:             types.StringValue(loadbalancer.Status),
 	}
 
 	// Set refreshed state
@@ -306,7 +328,9 @@ func (s *LoadbalancerResource) Read(ctx context.Context, req resource.ReadReques
 }
 
 func (s *LoadbalancerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// updating resource is not supported
+	// other changes replace the loadbalancer
+	resp.State.Raw = req.State.Raw.Copy()
+	adoptPlannedAttributes(ctx, req, resp, "deletion_protection")
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -319,6 +343,10 @@ func (s *LoadbalancerResource) Delete(ctx context.Context, req resource.DeleteRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, "loadbalancer", state.ID.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	tflog.Debug(ctx, "send delete loadbalancer request")
 	// delete loadbalancer
 	_, err := s.client.Loadbalancers().Delete(state.ID.ValueString())
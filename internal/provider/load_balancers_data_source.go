@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// NewLoadbalancersDataSource is a helper function to simplify the provider implementation.
+func NewLoadbalancersDataSource() datasource.DataSource {
+	return &listDataSource{
+		typeName:    "_loadbalancers",
+		attribute:   "loadbalancers",
+		description: "Load balancers",
+		itemAttributes: map[string]schema.Attribute{
+			"id":           schema.StringAttribute{Computed: true, Description: "id"},
+			"name":         schema.StringAttribute{Computed: true, Description: "name"},
+			"type":         schema.StringAttribute{Computed: true, Description: "type"},
+			"ip":           schema.StringAttribute{Computed: true, Description: "ip"},
+			"algorithm":    schema.StringAttribute{Computed: true, Description: "algorithm"},
+			"country":      schema.StringAttribute{Computed: true, Description: "country"},
+			"city":         schema.StringAttribute{Computed: true, Description: "city"},
+			"backendcount": schema.StringAttribute{Computed: true, Description: "backendcount"},
+			"status":       schema.StringAttribute{Computed: true, Description: "status"},
+			"created_at":   schema.StringAttribute{Computed: true, Description: "created_at"},
+		},
+		list: listLoadbalancers,
+	}
+}
+
+func listLoadbalancers(client utho.Client) ([]map[string]attr.Value, error) {
+	loadbalancers, err := client.Loadbalancers().List()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]attr.Value, 0, len(loadbalancers))
+	for _, loadbalancer := range loadbalancers {
+		items = append(items, map[string]attr.Value{
+			"id":           types.StringValue(loadbalancer.ID),
+			"name":         types.StringValue(loadbalancer.Name),
+			"type":         types.StringValue(loadbalancer.Type),
+			"ip":           types.StringValue(loadbalancer.IP),
+			"algorithm":    types.StringValue(loadbalancer.Algorithm),
+			"country":      types.StringValue(loadbalancer.Country),
+			"city":         types.StringValue(loadbalancer.City),
+			"backendcount": types.StringValue(loadbalancer.Backendcount),
+			"status":       types.StringValue(loadbalancer.Status),
+			"created_at":   types.StringValue(loadbalancer.CreatedAt),
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+var (
+	_ datasource.DataSource              = &PlanDataSource{}
+	_ datasource.DataSourceWithConfigure = &PlanDataSource{}
+)
+
+type PlanDataSource struct {
+	client utho.Client
+}
+
+type PlanDataSourceConfigModel struct {
+	Dcslug         types.String  `tfsdk:"dcslug"`
+	MinCPU         types.Int64   `tfsdk:"min_cpu"`
+	MinRAM         types.Int64   `tfsdk:"min_ram"`
+	MinDisk        types.Int64   `tfsdk:"min_disk"`
+	Plantype       types.String  `tfsdk:"plantype"`
+	MaxPrice       types.Float64 `tfsdk:"max_price"`
+	ID             types.String  `tfsdk:"id"`
+	UUID           types.String  `tfsdk:"uuid"`
+	Type           types.String  `tfsdk:"type"`
+	Slug           types.String  `tfsdk:"slug"`
+	Name           types.String  `tfsdk:"name"`
+	Description    types.String  `tfsdk:"description"`
+	Disk           types.String  `tfsdk:"disk"`
+	RAM            types.String  `tfsdk:"ram"`
+	CPU            types.String  `tfsdk:"cpu"`
+	Bandwidth      types.String  `tfsdk:"bandwidth"`
+	DedicatedVcore types.String  `tfsdk:"dedicated_vcore"`
+	Price          types.Int64   `tfsdk:"price"`
+	Monthly        types.String  `tfsdk:"monthly"`
+}
+
+func NewPlanDataSource() datasource.DataSource {
+	return &PlanDataSource{}
+}
+
+func (*PlanDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plan"
+}
+
+// Schema defines the schema for the data source.
+func (d *PlanDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := planAttributes()
+	for name, attribute := range planFilterAttributes() {
+		attributes[name] = attribute
+	}
+	attributes["plantype"] = schema.StringAttribute{Optional: true, Computed: true, Description: "Plan type eg: basic, dedicated, gpu"}
+
+	resp.Schema = schema.Schema{
+		Description: "Selects the cheapest cloud instance plan satisfying the constraints, reading fails when no plan does.",
+		Attributes:  attributes,
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *PlanDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(utho.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Plan Data Source Configure Type",
+			fmt.Sprintf("Expected utho.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *PlanDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read `plan` data source")
+	var state PlanDataSourceConfigModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get plans
+	plans, err := listCloudPlans(d.client, state.Dcslug.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list `plans`",
+			err.Error(),
+		)
+		return
+	}
+	plans = filterCloudPlans(plans, state.MinCPU, state.MinRAM, state.MinDisk, state.Plantype, state.MaxPrice)
+	if len(plans) == 0 {
+		resp.Diagnostics.AddError(
+			"Unable to find `plan`",
+			"No plan satisfies the dcslug, min_cpu, min_ram, min_disk, plantype and max_price constraints",
+		)
+		return
+	}
+
+	// Map response body to model
+	plan := planDataSourceModel(cheapestCloudPlan(plans))
+	state.ID = plan.ID
+	state.UUID = plan.UUID
+	state.Type = plan.Type
+	if state.Plantype.IsNull() {
+		state.Plantype = plan.Plantype
+	}
+	state.Slug = plan.Slug
+	state.Name = plan.Name
+	state.Description = plan.Description
+	state.Disk = plan.Disk
+	state.RAM = plan.RAM
+	state.CPU = plan.CPU
+	state.Bandwidth = plan.Bandwidth
+	state.DedicatedVcore = plan.DedicatedVcore
+	state.Price = plan.Price
+	state.Monthly = plan.Monthly
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Finished reading `plan` data source", map[string]any{"success": true})
+}
+
+// cheapestCloudPlan returns the plan with the lowest monthly price, the first one on equal prices.
+func cheapestCloudPlan(plans []cloudPlan) cloudPlan {
+	monthly := func(plan cloudPlan) float64 {
+		price, err := strconv.ParseFloat(strings.TrimSpace(plan.Monthly), 64)
+		if err != nil {
+			return math.Inf(1)
+		}
+		return price
+	}
+	return slices.MinFunc(plans, func(a, b cloudPlan) int { return cmp.Compare(monthly(a), monthly(b)) })
+}
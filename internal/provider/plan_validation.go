@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// Values that depend on the account, like zones, plans and images, are checked against the api when planning.
+// ModifyPlan is used because the client is not configured yet when the configuration is validated.
+
+// planLookups caches the api lists used to validate plans for a configured client,
+// so a plan with many resources lists the zones, plans and images once.
+type planLookups struct {
+	mu          sync.Mutex
+	datacenters []string
+	plans       map[string][]cloudPlan
+	images      []string
+}
+
+// planLookupCache holds the planLookups of each configured client.
+var planLookupCache sync.Map
+
+func planLookupsFor(client utho.Client) *planLookups {
+	lookups, _ := planLookupCache.LoadOrStore(client, &planLookups{plans: map[string][]cloudPlan{}})
+	return lookups.(*planLookups)
+}
+
+// zones returns the slugs of the utho zones.
+func (l *planLookups) zones(client utho.Client) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.datacenters != nil {
+		return l.datacenters, nil
+	}
+
+	datacenters, err := listDatacenters(client)
+	if err != nil {
+		return nil, err
+	}
+	l.datacenters = make([]string, 0, len(datacenters))
+	for _, datacenter := range datacenters {
+		l.datacenters = append(l.datacenters, datacenter.Slug)
+	}
+	return l.datacenters, nil
+}
+
+// cloudPlans returns the cloud plans offered in the zone.
+func (l *planLookups) cloudPlans(client utho.Client, dcslug string) ([]cloudPlan, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if plans, ok := l.plans[dcslug]; ok {
+		return plans, nil
+	}
+
+	plans, err := listCloudPlans(client, dcslug)
+	if err != nil {
+		return nil, err
+	}
+	l.plans[dcslug] = plans
+	return plans, nil
+}
+
+// planIDs returns the ids of the cloud plans offered in the zone.
+func (l *planLookups) planIDs(client utho.Client, dcslug string) ([]string, error) {
+	plans, err := l.cloudPlans(client, dcslug)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(plans))
+	for _, plan := range plans {
+		ids = append(ids, plan.ID)
+	}
+	return ids, nil
+}
+
+// imageNames returns the names of the os images.
+func (l *planLookups) imageNames(client utho.Client) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.images != nil {
+		return l.images, nil
+	}
+
+	images, err := client.CloudInstances().ListOsImages()
+	if err != nil {
+		return nil, err
+	}
+	l.images = make([]string, 0, len(images))
+	for _, image := range images {
+		l.images = append(l.images, image.Image)
+	}
+	return l.images, nil
+}
+
+// plannedChange returns the planned value of a string attribute when it is known and differs from the state.
+func plannedChange(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attribute string) (string, bool) {
+	var planned, current types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attribute), &planned)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attribute), &current)...)
+	}
+	if resp.Diagnostics.HasError() || planned.IsNull() || planned.IsUnknown() || planned.Equal(current) {
+		return "", false
+	}
+	return planned.ValueString(), true
+}
+
+// validateDcslugPlan checks a new dcslug against the utho zones.
+func validateDcslugPlan(ctx context.Context, client utho.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+	dcslug, changed := plannedChange(ctx, req, resp, "dcslug")
+	if !changed {
+		return
+	}
+
+	zones, err := planLookupsFor(client).zones(client)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("dcslug"), "Unable to validate dcslug", "Could not list utho zones: "+err.Error())
+		return
+	}
+	if len(zones) == 0 || slices.Contains(zones, dcslug) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(path.Root("dcslug"), "Invalid dcslug", unknownValueMessage("zone", dcslug, zones))
+}
+
+// validatePlanidPlan checks that a new planid is offered in the zone of the resource.
+// It is skipped when the zone is unknown or invalid.
+func validatePlanidPlan(ctx context.Context, client utho.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if client == nil || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+	planid, planidChanged := plannedChange(ctx, req, resp, "planid")
+	_, dcslugChanged := plannedChange(ctx, req, resp, "dcslug")
+	if !planidChanged && !dcslugChanged {
+		return
+	}
+
+	var dcslug types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("dcslug"), &dcslug)...)
+	if !planidChanged {
+		var planned types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("planid"), &planned)...)
+		if planned.IsNull() || planned.IsUnknown() {
+			return
+		}
+		planid = planned.ValueString()
+	}
+	if resp.Diagnostics.HasError() || dcslug.IsNull() || dcslug.IsUnknown() {
+		return
+	}
+
+	ids, err := planLookupsFor(client).planIDs(client, dcslug.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("planid"), "Unable to validate planid", "Could not list utho plans: "+err.Error())
+		return
+	}
+	if len(ids) == 0 || slices.Contains(ids, planid) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("planid"),
+		"Invalid planid",
+		fmt.Sprintf("Plan %q is not offered in zone %q. Use the utho_plans data source to find the plans available in the zone", planid, dcslug.ValueString()),
+	)
+}
+
+// validateImagePlan checks a new image name, held by the attribute, against the utho os images.
+func validateImagePlan(ctx context.Context, client utho.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attribute string) {
+	if client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+	image, changed := plannedChange(ctx, req, resp, attribute)
+	if !changed {
+		return
+	}
+
+	images, err := planLookupsFor(client).imageNames(client)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root(attribute), "Unable to validate "+attribute, "Could not list utho images: "+err.Error())
+		return
+	}
+	if len(images) == 0 || slices.Contains(images, image) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid "+attribute, unknownValueMessage("image", image, images))
+}
+
+// unknownValueMessage describes a value missing from the candidates, suggesting the closest one.
+func unknownValueMessage(kind, value string, candidates []string) string {
+	message := fmt.Sprintf("%q is not an available utho %s.", value, kind)
+	if suggestion := closestMatch(value, candidates); suggestion != "" {
+		message += fmt.Sprintf(" Did you mean %q?", suggestion)
+	}
+	sorted := slices.Clone(candidates)
+	slices.Sort(sorted)
+	return message + " Available values: " + strings.Join(sorted, ", ")
+}
+
+// closestMatch returns the candidate with the smallest edit distance to value,
+// or an empty string when none is close enough to be a typo.
+func closestMatch(value string, candidates []string) string {
+	best, bestDistance := "", max(2, len(value)/3)+1
+	for _, candidate := range candidates {
+		if distance := editDistance(strings.ToLower(value), strings.ToLower(candidate)); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance is the levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+func TestAccDcslugPlanValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "utho_vpc" "example" {
+	dcslug  = "innodia"
+	name    = "example-validation"
+	planid  = "1008"
+	network = "10.210.100.0"
+	size    = "24"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Did you mean "innoida"\?`),
+			},
+		},
+	})
+}
+
+func TestAccPlanidPlanValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "utho_cloud_instance" "example" {
+	name          = "example-validation"
+	dcslug        = "innoida"
+	image         = "ubuntu-22.04-x86_64"
+	planid        = "1"
+	root_password = "qwe123"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Plan "1" is not offered in zone "innoida"`),
+			},
+		},
+	})
+}
+
+func TestAccStackimagePlanValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "utho_auto_scaling" "example" {
+	name                = "example-validation"
+	os_disk_size        = 800
+	dcslug              = "inmumbaizone2"
+	minsize             = 1
+	maxsize             = 2
+	desiredsize         = 1
+	planid              = "10045"
+	planname            = "basic"
+	instance_templateid = "none"
+	public_ip_enabled   = "true"
+	stackid             = "6669341"
+	stackimage          = "ubuntu-22.04-x86"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Did you mean "ubuntu-22.04-x86_64"\?`),
+			},
+		},
+	})
+}
+
+func TestClosestMatch(t *testing.T) {
+	zones := []string{"innoida", "inmumbaizone2", "inbangalore", "uslosangeles"}
+
+	tests := map[string]string{
+		"innodia":       "innoida",
+		"INNOIDA":       "innoida",
+		"inmumbaizone1": "inmumbaizone2",
+		"inbanglore":    "inbangalore",
+		"frankfurt":     "",
+	}
+	for value, want := range tests {
+		if got := closestMatch(value, zones); got != want {
+			t.Errorf("closestMatch(%q): expected %q, got %q", value, want, got)
+		}
+	}
+}
+
+func TestUnknownValueMessage(t *testing.T) {
+	message := unknownValueMessage("zone", "innodia", []string{"inmumbaizone2", "innoida"})
+	want := `"innodia" is not an available utho zone. Did you mean "innoida"? Available values: inmumbaizone2, innoida`
+	if message != want {
+		t.Errorf("expected %q, got %q", want, message)
+	}
+}
+
+// fakePlanClient answers the pricing requests used by plan validation and counts them.
+type fakePlanClient struct {
+	utho.Client
+	requests int
+}
+
+func (c *fakePlanClient) NewRequest(method, url string, _ ...interface{}) (*http.Request, error) {
+	return http.NewRequest(method, "https://api.utho.com/v2/"+url, nil)
+}
+
+func (c *fakePlanClient) Do(req *http.Request, v interface{}) (*http.Response, error) {
+	c.requests++
+	body := `{"status":"success","pricing":[{"id":"10045"},{"id":"10046"}]}`
+	if req.URL.Query().Get("dcslug") == "inmumbaizone2" {
+		body = `{"status":"success","pricing":[{"id":"10046"}]}`
+	}
+	return nil, json.Unmarshal([]byte(body), v)
+}
+
+func TestPlanLookupsCache(t *testing.T) {
+	client := &fakePlanClient{}
+	lookups := planLookupsFor(client)
+	if planLookupsFor(client) != lookups {
+		t.Fatal("expected the lookups of a client to be shared")
+	}
+
+	for i := 0; i < 3; i++ {
+		ids, err := lookups.planIDs(client, "innoida")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != 2 {
+			t.Fatalf("expected 2 plans, got %v", ids)
+		}
+	}
+	ids, err := lookups.planIDs(client, "inmumbaizone2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != "10046" {
+		t.Fatalf("expected plan 10046, got %v", ids)
+	}
+	if client.requests != 2 {
+		t.Errorf("expected one request per zone, got %d", client.requests)
+	}
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+var (
+	_ datasource.DataSource              = &PlansDataSource{}
+	_ datasource.DataSourceWithConfigure = &PlansDataSource{}
+)
+
+type PlansDataSource struct {
+	client utho.Client
+}
+type PlansDataSourceModel struct {
+	Dcslug   types.String          `tfsdk:"dcslug"`
+	MinCPU   types.Int64           `tfsdk:"min_cpu"`
+	MinRAM   types.Int64           `tfsdk:"min_ram"`
+	MinDisk  types.Int64           `tfsdk:"min_disk"`
+	Plantype types.String          `tfsdk:"plantype"`
+	MaxPrice types.Float64         `tfsdk:"max_price"`
+	Plans    []PlanDataSourceModel `tfsdk:"plans"`
+}
+type PlanDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	UUID           types.String `tfsdk:"uuid"`
+	Type           types.String `tfsdk:"type"`
+	Plantype       types.String `tfsdk:"plantype"`
+	Slug           types.String `tfsdk:"slug"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	Disk           types.String `tfsdk:"disk"`
+	RAM            types.String `tfsdk:"ram"`
+	CPU            types.String `tfsdk:"cpu"`
+	Bandwidth      types.String `tfsdk:"bandwidth"`
+	DedicatedVcore types.String `tfsdk:"dedicated_vcore"`
+	Price          types.Int64  `tfsdk:"price"`
+	Monthly        types.String `tfsdk:"monthly"`
+}
+
+func NewPlansDataSource() datasource.DataSource {
+	return &PlansDataSource{}
+}
+
+func (*PlansDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plans"
+}
+
+// planFilterAttributes are the constraints shared by utho_plans and utho_plan.
+func planFilterAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"dcslug":    schema.StringAttribute{Optional: true, Description: "Only return plans available in the zone eg: innoida"},
+		"min_cpu":   schema.Int64Attribute{Optional: true, Description: "Minimum number of cpu cores"},
+		"min_ram":   schema.Int64Attribute{Optional: true, Description: "Minimum ram in MB"},
+		"min_disk":  schema.Int64Attribute{Optional: true, Description: "Minimum disk size in GB"},
+		"plantype":  schema.StringAttribute{Optional: true, Description: "Plan type eg: basic, dedicated, gpu"},
+		"max_price": schema.Float64Attribute{Optional: true, Description: "Maximum monthly price"},
+	}
+}
+
+// planAttributes are the attributes of a plan.
+func planAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id":              schema.StringAttribute{Computed: true, Description: "id, use it as the planid of a cloud instance"},
+		"uuid":            schema.StringAttribute{Computed: true, Description: "uuid"},
+		"type":            schema.StringAttribute{Computed: true, Description: "type"},
+		"plantype":        schema.StringAttribute{Computed: true, Description: "plantype"},
+		"slug":            schema.StringAttribute{Computed: true, Description: "slug"},
+		"name":            schema.StringAttribute{Computed: true, Description: "name"},
+		"description":     schema.StringAttribute{Computed: true, Description: "description"},
+		"disk":            schema.StringAttribute{Computed: true, Description: "disk"},
+		"ram":             schema.StringAttribute{Computed: true, Description: "ram"},
+		"cpu":             schema.StringAttribute{Computed: true, Description: "cpu"},
+		"bandwidth":       schema.StringAttribute{Computed: true, Description: "bandwidth"},
+		"dedicated_vcore": schema.StringAttribute{Computed: true, Description: "dedicated_vcore"},
+		"price":           schema.Int64Attribute{Computed: true, Description: "price"},
+		"monthly":         schema.StringAttribute{Computed: true, Description: "monthly"},
+	}
+}
+
+// Schema defines the schema for the data source.
+func (d *PlansDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := planFilterAttributes()
+	attributes["plans"] = schema.ListNestedAttribute{
+		Computed:     true,
+		Description:  "cloud instance plans",
+		NestedObject: schema.NestedAttributeObject{Attributes: planAttributes()},
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *PlansDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(utho.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Plans Data Source Configure Type",
+			fmt.Sprintf("Expected utho.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *PlansDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read `plans` data source")
+	var state PlansDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get plans
+	plans, err := listCloudPlans(d.client, state.Dcslug.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list `plans`",
+			err.Error(),
+		)
+		return
+	}
+	plans = filterCloudPlans(plans, state.MinCPU, state.MinRAM, state.MinDisk, state.Plantype, state.MaxPrice)
+
+	// Map response body to model
+	state.Plans = []PlanDataSourceModel{}
+	for _, plan := range plans {
+		state.Plans = append(state.Plans, planDataSourceModel(plan))
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Finished reading `plans` data source", map[string]any{"success": true})
+}
+
+func planDataSourceModel(plan cloudPlan) PlanDataSourceModel {
+	return PlanDataSourceModel{
+		ID:             types.StringValue(plan.ID),
+		UUID:           types.StringValue(plan.UUID),
+		Type:           types.StringValue(plan.Type),
+		Plantype:       types.StringValue(plan.Plantype),
+		Slug:           types.StringValue(plan.Slug),
+		Name:           types.StringValue(plan.Name),
+		Description:    types.StringValue(plan.Description),
+		Disk:           types.StringValue(plan.Disk),
+		RAM:            types.StringValue(plan.RAM),
+		CPU:            types.StringValue(plan.CPU),
+		Bandwidth:      types.StringValue(plan.Bandwidth),
+		DedicatedVcore: types.StringValue(plan.DedicatedVcore),
+		Price:          types.Int64Value(int64(plan.Price)),
+		Monthly:        types.StringValue(plan.Monthly),
+	}
+}
+
+// filterCloudPlans returns the plans satisfying the set constraints, in the api order.
+func filterCloudPlans(plans []cloudPlan, minCPU, minRAM, minDisk types.Int64, plantype types.String, maxPrice types.Float64) []cloudPlan {
+	filtered := []cloudPlan{}
+	for _, plan := range plans {
+		if !minCPU.IsNull() && planNumber(plan.CPU) < float64(minCPU.ValueInt64()) {
+			continue
+		}
+		if !minRAM.IsNull() && planNumber(plan.RAM) < float64(minRAM.ValueInt64()) {
+			continue
+		}
+		if !minDisk.IsNull() && planNumber(plan.Disk) < float64(minDisk.ValueInt64()) {
+			continue
+		}
+		if !plantype.IsNull() && !strings.EqualFold(plan.Plantype, plantype.ValueString()) {
+			continue
+		}
+		if !maxPrice.IsNull() {
+			monthly, err := strconv.ParseFloat(strings.TrimSpace(plan.Monthly), 64)
+			if err != nil || monthly > maxPrice.ValueFloat64() {
+				continue
+			}
+		}
+		filtered = append(filtered, plan)
+	}
+	return filtered
+}
+
+var planNumberRegex = regexp.MustCompile(`^\s*([0-9]+(\.[0-9]+)?)`)
+
+// planNumber returns the leading number of a plan value eg: 2048 or 50GB, 0 when there is none.
+func planNumber(value string) float64 {
+	match := planNumberRegex.FindStringSubmatch(value)
+	if match == nil {
+		return 0
+	}
+	number, _ := strconv.ParseFloat(match[1], 64)
+	return number
+}
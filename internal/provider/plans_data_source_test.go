@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+func TestAccPlansDataSource(t *testing.T) {
+	resourceName := "data.utho_plans.example"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "utho_plans" "example" {
+	dcslug  = "innoida"
+	min_cpu = 2
+	min_ram = 4096
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "plans.0.id"),
+					resource.TestCheckResourceAttrSet(resourceName, "plans.0.cpu"),
+					resource.TestCheckResourceAttrSet(resourceName, "plans.0.monthly"),
+				),
+			},
+		},
+	})
+}
+
+func testCloudPlans() []cloudPlan {
+	return []cloudPlan{
+		{Pricing: utho.Pricing{ID: "10045", CPU: "1", RAM: "2048", Disk: "40", Monthly: "600"}, Plantype: "basic"},
+		{Pricing: utho.Pricing{ID: "10046", CPU: "2", RAM: "4096", Disk: "80GB", Monthly: "1200"}, Plantype: "basic"},
+		{Pricing: utho.Pricing{ID: "10047", CPU: "2", RAM: "4096", Disk: "80", Monthly: "1100.50"}, Plantype: "Dedicated"},
+		{Pricing: utho.Pricing{ID: "10048", CPU: "8", RAM: "16384", Disk: "160", Monthly: "9000"}, Plantype: "gpu"},
+	}
+}
+
+func TestFilterCloudPlans(t *testing.T) {
+	tests := []struct {
+		name     string
+		minCPU   types.Int64
+		minRAM   types.Int64
+		minDisk  types.Int64
+		plantype types.String
+		maxPrice types.Float64
+		want     []string
+	}{
+		{name: "no constraints", want: []string{"10045", "10046", "10047", "10048"}},
+		{name: "min cpu and ram", minCPU: types.Int64Value(2), minRAM: types.Int64Value(4096), want: []string{"10046", "10047", "10048"}},
+		{name: "min disk with unit", minDisk: types.Int64Value(80), maxPrice: types.Float64Value(1200), want: []string{"10046", "10047"}},
+		{name: "plantype", plantype: types.StringValue("dedicated"), want: []string{"10047"}},
+	}
+
+	for _, test := range tests {
+		var got []string
+		for _, plan := range filterCloudPlans(testCloudPlans(), test.minCPU, test.minRAM, test.minDisk, test.plantype, test.maxPrice) {
+			got = append(got, plan.ID)
+		}
+		if len(got) != len(test.want) {
+			t.Fatalf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+			}
+		}
+	}
+}
+
+func TestCheapestCloudPlan(t *testing.T) {
+	plans := filterCloudPlans(testCloudPlans(), types.Int64Value(2), types.Int64Null(), types.Int64Null(), types.StringNull(), types.Float64Null())
+	if plan := cheapestCloudPlan(plans); plan.ID != "10047" {
+		t.Errorf("expected plan 10047, got %s", plan.ID)
+	}
+}
@@ -23,7 +23,22 @@ type (
 	}
 
 	uthoProviderModel struct {
-		Token types.String `tfsdk:"token"`
+		Token          types.String      `tfsdk:"token"`
+		MaxMonthlyCost types.Float64     `tfsdk:"max_monthly_cost"`
+		DefaultTags    *defaultTagsModel `tfsdk:"default_tags"`
+	}
+
+	defaultTagsModel struct {
+		Tags types.Map `tfsdk:"tags"`
+	}
+
+	// providerClient is the client passed to resources, with the provider settings they apply.
+	providerClient struct {
+		utho.Client
+		// maxMonthlyCost is the estimated monthly cost a cloud instance may not exceed, unlimited when null.
+		maxMonthlyCost types.Float64
+		// defaultTags are merged into the tags of every taggable resource.
+		defaultTags map[string]string
 	}
 )
 
@@ -50,6 +65,20 @@ func (p *uthoProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Sensitive:   true,
 				Description: "Utho token",
 			},
+			"max_monthly_cost": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Fail the plan when the estimated monthly cost of a cloud instance is higher than this budget",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"default_tags": schema.SingleNestedBlock{
+				Description: "Tags added to every taggable resource, tags set on a resource take precedence",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{Optional: true, ElementType: types.StringType, Validators: tagsValidators(),
+						Description: "Default tags. Keys can not contain , or : and values can not contain ,",
+					},
+				},
+			},
 		},
 	}
 }
@@ -74,6 +103,19 @@ func (p *uthoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		)
 	}
 
+	var defaultTags map[string]string
+	if config.DefaultTags != nil {
+		if config.DefaultTags.Tags.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("default_tags").AtName("tags"),
+				"Unknown default tags",
+				"The provider default_tags must be known when the provider is configured, they can not depend on resources",
+			)
+		} else {
+			resp.Diagnostics.Append(config.DefaultTags.Tags.ElementsAs(ctx, &defaultTags, false)...)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -119,7 +161,7 @@ func (p *uthoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	// Make the Token client available during DataSource and Resource
 
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &providerClient{Client: client, maxMonthlyCost: config.MaxMonthlyCost, defaultTags: defaultTags}
 
 	tflog.Info(ctx, "Configured utho client", map[string]any{"success": true})
 }
@@ -128,8 +170,26 @@ func (p *uthoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 func (p *uthoProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewAccountDataSource,
+		NewCloudinitConfigDataSource,
+		NewDatacentersDataSource,
+		NewImageDataSource,
 		NewImagesDataSource,
+		NewInstanceTemplateDataSource,
 		NewObjectStoragePlanDataSource,
+		NewPlanDataSource,
+		NewPlansDataSource,
+		NewDomainDataSource,
+		NewVpcDataSource,
+		NewFirewallDataSource,
+		NewLoadbalancerDataSource,
+		NewCloudInstanceDataSource,
+		NewTargetGroupDataSource,
+		NewAutoScalingDataSource,
+		NewCloudInstancesDataSource,
+		NewVpcsDataSource,
+		NewFirewallsDataSource,
+		NewLoadbalancersDataSource,
+		NewDomainsDataSource,
 	}
 }
 
@@ -143,6 +203,13 @@ func (p *uthoProvider) Resources(_ context.Context) []func() resource.Resource {
 		NewLoadbalancerResource,
 		NewCloudInstanceResource,
 		NewTargetGroupResource,
+		NewTargetGroupTargetResource,
 		NewAutoScalingResource,
+		NewAutoScalingPolicyResource,
+		NewAutoScalingScheduleResource,
+		NewInstanceTemplateResource,
+		NewReverseDnsResource,
+		NewCustomIsoResource,
+		NewVpcSubnetResource,
 	}
 }
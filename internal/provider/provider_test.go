@@ -1,8 +1,12 @@
 package provider
 
 import (
+	"context"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 const (
@@ -19,3 +23,16 @@ var (
 		"utho": providerserver.NewProtocol6WithError(New("test")()),
 	}
 )
+
+// testObject returns an object of the schema with the overridden attributes, the other attributes are null.
+func testObject(s schema.Schema, overrides map[string]tftypes.Value) tftypes.Value {
+	objectType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for attributeName, attributeType := range objectType.AttributeTypes {
+		values[attributeName] = tftypes.NewValue(attributeType, nil)
+	}
+	for attributeName, value := range overrides {
+		values[attributeName] = value
+	}
+	return tftypes.NewValue(objectType, values)
+}
@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+var (
+	_ datasource.DataSource                     = &resourceDataSource{}
+	_ datasource.DataSourceWithConfigure        = &resourceDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &resourceDataSource{}
+)
+
+// resourceDataSource looks up an existing object of a resource type.
+// The schema is the resource schema with every attribute computed and the state is filled by the resource Read,
+// so the data source always has the same attributes as the resource.
+type resourceDataSource struct {
+	client utho.Client
+
+	// resource returns the resource the data source reads.
+	resource func() resource.Resource
+	// idAttribute is the attribute the resource Read uses to find the object.
+	idAttribute string
+	// lookup returns the ids of the objects with the given name, in dcslug when it is not empty.
+	// The object can only be looked up by idAttribute when it is nil.
+	lookup func(client utho.Client, name, dcslug string) ([]string, error)
+	// dcslug is set when the lookup can match the name in a single zone.
+	dcslug bool
+}
+
+func (d *resourceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	var metadata resource.MetadataResponse
+	d.resource().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: req.ProviderTypeName}, &metadata)
+	resp.TypeName = metadata.TypeName
+}
+
+// Schema defines the schema for the data source.
+func (d *resourceDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resourceSchema := d.resourceSchema(ctx)
+
+	attributes := dataSourceAttributes(resourceSchema.Attributes)
+	attributes[d.idAttribute] = lookupAttribute(attributes[d.idAttribute], d.lookup == nil)
+	if d.lookup != nil {
+		attributes["name"] = lookupAttribute(attributes["name"], false)
+		if d.dcslug {
+			attributes["dcslug"] = lookupAttribute(attributes["dcslug"], false)
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description:         resourceSchema.Description,
+		MarkdownDescription: resourceSchema.MarkdownDescription,
+		Attributes:          attributes,
+	}
+}
+
+// ConfigValidators requires the object to be looked up either by id or by name.
+func (d *resourceDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	if d.lookup == nil {
+		return nil
+	}
+
+	validators := []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot(d.idAttribute),
+			path.MatchRoot("name"),
+		),
+	}
+	if d.dcslug {
+		validators = append(validators, datasourcevalidator.Conflicting(
+			path.MatchRoot(d.idAttribute),
+			path.MatchRoot("dcslug"),
+		))
+	}
+	return validators
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *resourceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(utho.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected utho.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *resourceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read resource data source")
+	var id types.String
+	diags := req.Config.GetAttribute(ctx, path.Root(d.idAttribute), &id)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the id of the named object
+	if id.IsNull() {
+		var name, dcslug types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+		if d.dcslug {
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dcslug"), &dcslug)...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		ids, err := d.lookup(d.client, name.ValueString(), dcslug.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to list utho objects",
+				err.Error(),
+			)
+			return
+		}
+		if len(ids) != 1 {
+			resp.Diagnostics.AddError(
+				"Unable to find utho object",
+				fmt.Sprintf("Expected exactly one object named %q, found %d", name.ValueString(), len(ids)),
+			)
+			return
+		}
+		id = types.StringValue(ids[0])
+	}
+
+	// read the object with the resource, starting from a state that only holds its id
+	r := d.resource()
+	if configurable, ok := r.(resource.ResourceWithConfigure); ok {
+		var configureResp resource.ConfigureResponse
+		configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: d.client}, &configureResp)
+		resp.Diagnostics.Append(configureResp.Diagnostics...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	state, diags := resourceDataSourceState(ctx, d.resourceSchema(ctx), d.idAttribute, id)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readResp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &readResp)
+	resp.Diagnostics.Append(readResp.Diagnostics...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	resp.State.Raw = readResp.State.Raw
+	tflog.Debug(ctx, "Finished reading resource data source", map[string]any{"success": true})
+}
+
+func (d *resourceDataSource) resourceSchema(ctx context.Context) rschema.Schema {
+	var schemaResp resource.SchemaResponse
+	d.resource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	return schemaResp.Schema
+}
+
+// resourceDataSourceState returns a resource state where only the id attribute is set.
+func resourceDataSourceState(ctx context.Context, resourceSchema rschema.Schema, idAttribute string, id types.String) (tfsdk.State, diag.Diagnostics) {
+	state := tfsdk.State{
+		Schema: resourceSchema,
+		Raw:    tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.SetAttribute(ctx, path.Root(idAttribute), id)
+	return state, diags
+}
+
+// lookupAttribute makes a computed string attribute configurable so it can be used to find the object.
+func lookupAttribute(attribute schema.Attribute, required bool) schema.Attribute {
+	description := attribute.GetDescription()
+	markdownDescription := attribute.GetMarkdownDescription()
+	if required {
+		return schema.StringAttribute{Required: true, Description: description, MarkdownDescription: markdownDescription}
+	}
+	return schema.StringAttribute{Optional: true, Computed: true, Description: description, MarkdownDescription: markdownDescription}
+}
+
+// dataSourceAttributes converts resource attributes to computed data source attributes of the same type.
+func dataSourceAttributes(attributes map[string]rschema.Attribute) map[string]schema.Attribute {
+	converted := make(map[string]schema.Attribute, len(attributes))
+	for name, attribute := range attributes {
+		converted[name] = dataSourceAttribute(attribute)
+	}
+	return converted
+}
+
+func dataSourceAttribute(attribute rschema.Attribute) schema.Attribute {
+	description := attribute.GetDescription()
+	markdownDescription := attribute.GetMarkdownDescription()
+	sensitive := attribute.IsSensitive()
+
+	switch a := attribute.(type) {
+	case rschema.StringAttribute:
+		return schema.StringAttribute{Computed: true, Sensitive: sensitive, Description: description, MarkdownDescription: markdownDescription}
+	case rschema.Int64Attribute:
+		return schema.Int64Attribute{Computed: true, Sensitive: sensitive, Description: description, MarkdownDescription: markdownDescription}
+	case rschema.Float64Attribute:
+		return schema.Float64Attribute{Computed: true, Sensitive: sensitive, Description: description, MarkdownDescription: markdownDescription}
+	case rschema.BoolAttribute:
+		return schema.BoolAttribute{Computed: true, Sensitive: sensitive, Description: description, MarkdownDescription: markdownDescription}
+	case rschema.ListAttribute:
+		return schema.ListAttribute{Computed: true, ElementType: a.ElementType, Sensitive: sensitive, Description: description, MarkdownDescription: markdownDescription}
+	case rschema.SetAttribute:
+		return schema.SetAttribute{Computed: true, ElementType: a.ElementType, Sensitive: sensitive, Description: description, MarkdownDescription: markdownDescription}
+	case rschema.MapAttribute:
+		return schema.MapAttribute{Computed: true, ElementType: a.ElementType, Sensitive: sensitive, Description: description, MarkdownDescription: markdownDescription}
+	case rschema.ListNestedAttribute:
+		return schema.ListNestedAttribute{
+			Computed:            true,
+			Sensitive:           sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			NestedObject:        schema.NestedAttributeObject{Attributes: dataSourceAttributes(a.NestedObject.Attributes)},
+		}
+	case rschema.SingleNestedAttribute:
+		return schema.SingleNestedAttribute{
+			Computed:            true,
+			Sensitive:           sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			Attributes:          dataSourceAttributes(a.Attributes),
+		}
+	}
+
+	panic(fmt.Sprintf("resource attribute type %T is not supported by data sources", attribute))
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResourceDataSourceSchema(t *testing.T) {
+	ctx := context.Background()
+
+	for _, newDataSource := range []func() datasource.DataSource{
+		NewAutoScalingDataSource,
+		NewCloudInstanceDataSource,
+		NewDomainDataSource,
+		NewFirewallDataSource,
+		NewLoadbalancerDataSource,
+		NewTargetGroupDataSource,
+		NewVpcDataSource,
+	} {
+		d := newDataSource().(*resourceDataSource)
+
+		var metadata datasource.MetadataResponse
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "utho"}, &metadata)
+
+		var schemaResp datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+		if schemaResp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected schema diagnostics: %v", metadata.TypeName, schemaResp.Diagnostics)
+		}
+
+		// the resource state is copied into the data source state, so the types must match
+		resourceSchema := d.resourceSchema(ctx)
+		if !schemaResp.Schema.Type().Equal(resourceSchema.Type()) {
+			t.Errorf("%s: data source type %s does not match resource type %s", metadata.TypeName, schemaResp.Schema.Type(), resourceSchema.Type())
+		}
+
+		lookup, diags := schemaResp.Schema.AttributeAtPath(ctx, path.Root(d.idAttribute))
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", metadata.TypeName, diags)
+		}
+		if !lookup.IsOptional() && !lookup.IsRequired() {
+			t.Errorf("%s: expected %s to be configurable", metadata.TypeName, d.idAttribute)
+		}
+
+		state, diags := resourceDataSourceState(ctx, resourceSchema, d.idAttribute, types.StringValue("12345"))
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", metadata.TypeName, diags)
+		}
+		var id types.String
+		state.GetAttribute(ctx, path.Root(d.idAttribute), &id)
+		if id.ValueString() != "12345" {
+			t.Errorf("%s: expected state %s 12345, got %s", metadata.TypeName, d.idAttribute, id)
+		}
+	}
+}
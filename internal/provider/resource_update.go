@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// adoptPlannedAttributes stores the planned values of attributes that are only kept in the state,
+// unknown values keep their current state.
+func adoptPlannedAttributes(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse, attributes ...string) {
+	for _, attribute := range attributes {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attribute), &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if value.IsUnknown() {
+			continue
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(attribute), value)...)
+	}
+}
@@ -0,0 +1,359 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// implement resource interfaces.
+var (
+	_ resource.Resource                   = &ReverseDnsResource{}
+	_ resource.ResourceWithConfigure      = &ReverseDnsResource{}
+	_ resource.ResourceWithImportState    = &ReverseDnsResource{}
+	_ resource.ResourceWithModifyPlan     = &ReverseDnsResource{}
+	_ resource.ResourceWithValidateConfig = &ReverseDnsResource{}
+)
+
+// NewReverseDnsResource is a helper function to simplify the provider implementation.
+func NewReverseDnsResource() resource.Resource {
+	return &ReverseDnsResource{}
+}
+
+// ReverseDnsResource is the resource implementation.
+type ReverseDnsResource struct {
+	client utho.Client
+}
+
+type ReverseDnsResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	IPAddress       types.String `tfsdk:"ip_address"`
+	Hostname        types.String `tfsdk:"hostname"`
+	CloudInstanceID types.String `tfsdk:"cloud_instance_id"`
+}
+
+// hostnameRegex matches a fully qualified domain name, with an optional trailing dot.
+var hostnameRegex = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z]{2,63}\.?$`)
+
+// lookupHost resolves the forward dns of a reverse dns hostname.
+var lookupHost = net.DefaultResolver.LookupHost
+
+// forwardDNSTimeout is how long the forward dns of a hostname may take to resolve.
+const forwardDNSTimeout = 5 * time.Second
+
+// Metadata returns the resource type name.
+func (s *ReverseDnsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reverse_dns"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ReverseDnsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(utho.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ReverseDns Data Source Configure Type",
+			fmt.Sprintf("Expected utho.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// Schema defines the schema for the resource.
+func (s *ReverseDnsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sets the reverse dns (PTR record) of a public ip of a cloud instance. Destroying the resource removes the reverse dns.",
+		Attributes: map[string]schema.Attribute{
+			"id":         schema.StringAttribute{Computed: true, Description: "The ip address", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"ip_address": schema.StringAttribute{Required: true, Description: "Public ipv4 or ipv6 address of a cloud instance", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"hostname": schema.StringAttribute{Required: true, Description: "Hostname the ip address resolves to, eg: mail.example.com. Its forward dns should resolve to the ip address",
+				Validators: []validator.String{stringvalidator.RegexMatches(hostnameRegex, "must be a fully qualified domain name")},
+			},
+			"cloud_instance_id": schema.StringAttribute{Optional: true, Computed: true, Description: "Id of the cloud instance with the ip address. Looked up from the ip address when not set",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace(), stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the ip address is valid.
+func (s *ReverseDnsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var ip types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ip_address"), &ip)...)
+	if resp.Diagnostics.HasError() || ip.IsNull() || ip.IsUnknown() {
+		return
+	}
+	if _, err := netip.ParseAddr(ip.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ip_address"), "Invalid ip_address", err.Error())
+	}
+}
+
+// ModifyPlan warns when the forward dns of the hostname does not resolve to the ip address.
+// Hostnames that can not be resolved are not checked.
+func (s *ReverseDnsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ReverseDnsResourceModel
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ip_address"), &plan.IPAddress)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("hostname"), &plan.Hostname)...)
+	if resp.Diagnostics.HasError() || plan.IPAddress.IsUnknown() || plan.Hostname.IsUnknown() {
+		return
+	}
+
+	if message, ok := checkForwardDNS(ctx, plan.Hostname.ValueString(), plan.IPAddress.ValueString()); !ok {
+		resp.Diagnostics.AddAttributeWarning(path.Root("hostname"), "Forward dns mismatch", message)
+	}
+}
+
+// checkForwardDNS returns whether the hostname resolves to the ip address, or can not be resolved.
+func checkForwardDNS(ctx context.Context, hostname, ip string) (string, bool) {
+	want, err := netip.ParseAddr(ip)
+	if err != nil {
+		return "", true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, forwardDNSTimeout)
+	defer cancel()
+	addrs, err := lookupHost(ctx, strings.TrimSuffix(hostname, "."))
+	if err != nil {
+		tflog.Debug(ctx, "skip forward dns check", map[string]any{"hostname": hostname, "error": err.Error()})
+		return "", true
+	}
+	for _, addr := range addrs {
+		if got, err := netip.ParseAddr(addr); err == nil && got.Unmap() == want.Unmap() {
+			return "", true
+		}
+	}
+	return fmt.Sprintf("%s resolves to %s, not to %s. Mail servers and other clients may reject the reverse dns until an A or AAAA record for %s points to %s",
+		hostname, strings.Join(addrs, ", "), ip, hostname, ip), false
+}
+
+// Import using the ip address as the attribute
+func (s *ReverseDnsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ip_address"), req.ID)...)
+}
+
+// Create a new resource.
+func (s *ReverseDnsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "create reverse dns")
+	// Retrieve values from plan
+	var plan ReverseDnsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cloudinstance, _, err := findCloudInstancePublicIP(s.client, plan.CloudInstanceID.ValueString(), plan.IPAddress.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating reverse dns",
+			"Could not find the cloud instance of ip "+plan.IPAddress.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "send update rdns request")
+	_, err = s.client.CloudInstances().UpdateRDNS(cloudinstance.ID, plan.IPAddress.ValueString(), utho.UpdateRDNSParams{Rdns: plan.Hostname.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating reverse dns",
+			"Could not set reverse dns of ip "+plan.IPAddress.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = types.StringValue(plan.IPAddress.ValueString())
+	plan.CloudInstanceID = types.StringValue(cloudinstance.ID)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish create reverse dns")
+}
+
+// Read resource information.
+func (s *ReverseDnsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "read reverse dns")
+
+	// Get current state
+	var state ReverseDnsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "send get cloud instance request")
+	cloudinstance, found, err := findCloudInstancePublicIP(s.client, state.CloudInstanceID.ValueString(), state.IPAddress.ValueString())
+	if errors.Is(err, errPublicIPNotFound) {
+		// the ip was released or its instance deleted, so is its reverse dns
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho reverse dns",
+			"Could not read reverse dns of ip "+state.IPAddress.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Overwrite items with refreshed state, the configured hostname is kept when it only differs in case or the trailing dot
+	// and the configured ip address when the api formats it differently
+	if state.IPAddress.IsNull() {
+		state.IPAddress = types.StringValue(found.IPAddress)
+	}
+	state.ID = state.IPAddress
+	state.CloudInstanceID = types.StringValue(cloudinstance.ID)
+	if !sameHostname(state.Hostname.ValueString(), found.Rdns) {
+		state.Hostname = types.StringValue(found.Rdns)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish get reverse dns request")
+}
+
+func (s *ReverseDnsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "update reverse dns")
+	// Retrieve values from plan
+	var plan ReverseDnsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "send update rdns request")
+	_, err := s.client.CloudInstances().UpdateRDNS(plan.CloudInstanceID.ValueString(), plan.IPAddress.ValueString(), utho.UpdateRDNSParams{Rdns: plan.Hostname.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating reverse dns",
+			"Could not set reverse dns of ip "+plan.IPAddress.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "finish update reverse dns")
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (s *ReverseDnsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "delete reverse dns")
+	// Get current state
+	var state ReverseDnsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "send update rdns request")
+	// an empty rdns removes the reverse dns
+	_, err := s.client.CloudInstances().UpdateRDNS(state.CloudInstanceID.ValueString(), state.IPAddress.ValueString(), utho.UpdateRDNSParams{Rdns: ""})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleteing utho reverse dns",
+			"Could not remove reverse dns of ip "+state.IPAddress.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// errPublicIPNotFound is returned when no cloud instance has the public ip.
+var errPublicIPNotFound = errors.New("no cloud instance has the public ip")
+
+// publicIP is an ipv4 or ipv6 public address of a cloud instance and its reverse dns.
+type publicIP struct {
+	IPAddress string
+	Rdns      string
+}
+
+// findCloudInstancePublicIP returns the cloud instance with the public ip, the instance is looked up when cloudId is empty.
+// A deleted instance has released its ips, so errPublicIPNotFound is returned for it as well.
+func findCloudInstancePublicIP(client utho.Client, cloudId, ip string) (*cloudInstance, publicIP, error) {
+	var cloudinstances []cloudInstance
+	if cloudId != "" {
+		cloudinstance, err := readCloudInstance(client, cloudId)
+		if errors.Is(err, errCloudInstanceNotFound) {
+			return nil, publicIP{}, errPublicIPNotFound
+		}
+		if err != nil {
+			return nil, publicIP{}, err
+		}
+		cloudinstances = append(cloudinstances, *cloudinstance)
+	} else {
+		var err error
+		cloudinstances, err = readCloudInstances(client)
+		if err != nil {
+			return nil, publicIP{}, err
+		}
+	}
+
+	for i := range cloudinstances {
+		if found, ok := findPublicIP(&cloudinstances[i], ip); ok {
+			return &cloudinstances[i], found, nil
+		}
+	}
+	return nil, publicIP{}, errPublicIPNotFound
+}
+
+// findPublicIP returns the ipv4 or ipv6 public ip of the cloud instance, addresses are compared in their parsed form.
+func findPublicIP(cloudinstance *cloudInstance, ip string) (publicIP, bool) {
+	want, err := netip.ParseAddr(ip)
+	if err != nil {
+		return publicIP{}, false
+	}
+	ips := []publicIP{}
+	for _, v := range cloudinstance.Networks.Public.V4 {
+		ips = append(ips, publicIP{IPAddress: v.IPAddress, Rdns: v.Rdns})
+	}
+	for _, v := range cloudinstance.Networks.Public.V6 {
+		ips = append(ips, publicIP{IPAddress: v.IPAddress, Rdns: v.Rdns})
+	}
+	for _, v := range ips {
+		if got, err := netip.ParseAddr(v.IPAddress); err == nil && got == want {
+			return v, true
+		}
+	}
+	return publicIP{}, false
+}
+
+// sameHostname compares hostnames ignoring case and the trailing dot.
+func sameHostname(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+func TestAccReverseDnsResource(t *testing.T) {
+	resourceName := "utho_reverse_dns.example"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "utho_cloud_instance" "example" {
+	name          = "example-name"
+	dcslug        = "inmumbaizone2"
+	image         = "ubuntu-22.04-x86_64"
+	planid        = "10045"
+	billingcycle  = "hourly"
+}
+
+resource "utho_reverse_dns" "example" {
+	ip_address = utho_cloud_instance.example.ip
+	hostname   = "mail.example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "hostname", "mail.example.com"),
+					resource.TestCheckResourceAttrPair(resourceName, "ip_address", "utho_cloud_instance.example", "ip"),
+					resource.TestCheckResourceAttrPair(resourceName, "cloud_instance_id", "utho_cloud_instance.example", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestCheckForwardDNS(t *testing.T) {
+	defer func(lookup func(context.Context, string) ([]string, error)) { lookupHost = lookup }(lookupHost)
+	lookupHost = func(_ context.Context, host string) ([]string, error) {
+		switch host {
+		case "mail.example.com":
+			return []string{"2401:db00::1", "103.146.242.55"}, nil
+		case "web.example.com":
+			return []string{"103.146.242.56"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	for name, tc := range map[string]struct {
+		hostname, ip string
+		want         bool
+	}{
+		"resolves":         {hostname: "mail.example.com", ip: "103.146.242.55", want: true},
+		"trailing dot":     {hostname: "mail.example.com.", ip: "103.146.242.55", want: true},
+		"ipv6":             {hostname: "mail.example.com", ip: "2401:db00:0::1", want: true},
+		"other ip":         {hostname: "web.example.com", ip: "103.146.242.55", want: false},
+		"does not resolve": {hostname: "new.example.com", ip: "103.146.242.55", want: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			message, ok := checkForwardDNS(context.Background(), tc.hostname, tc.ip)
+			if ok != tc.want {
+				t.Errorf("checkForwardDNS() = %t, want %t: %s", ok, tc.want, message)
+			}
+		})
+	}
+}
+
+func TestHostnameRegex(t *testing.T) {
+	for hostname, want := range map[string]bool{
+		"mail.example.com":   true,
+		"mail.example.com.":  true,
+		"a-b.example.co.in":  true,
+		"localhost":          false,
+		"-mail.example.com":  false,
+		"mail_1.example.com": false,
+	} {
+		if got := hostnameRegex.MatchString(hostname); got != want {
+			t.Errorf("hostnameRegex.MatchString(%q) = %t, want %t", hostname, got, want)
+		}
+	}
+
+	if !sameHostname("Mail.Example.com.", "mail.example.com") {
+		t.Error("hostnames differing in case and trailing dot should be the same")
+	}
+}
+
+func TestFindPublicIP(t *testing.T) {
+	cloudinstance := &cloudInstance{Networks: cloudInstanceNetworks{Public: cloudInstancePublicNetworks{
+		V4: utho.V4PublicArray{{IPAddress: "103.1.2.3", Rdns: "mail.example.com"}},
+		V6: []cloudInstanceV6{{IPAddress: "2401:db8:0:0::10", Rdns: "mail6.example.com"}},
+	}}}
+
+	for ip, want := range map[string]string{
+		"103.1.2.3":      "mail.example.com",
+		"2401:db8::10":   "mail6.example.com",
+		"2401:db8::11":   "",
+		"not an address": "",
+	} {
+		got, ok := findPublicIP(cloudinstance, ip)
+		if ok != (want != "") || got.Rdns != want {
+			t.Errorf("findPublicIP(%q) = %v, %t, want rdns %q", ip, got, ok, want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/uthoplatforms/terraform-provider-utho/helper"
+)
+
+// A cloud instance without a configured root_password gets a generated one, so passwords do not have to be written in the configuration.
+
+const (
+	defaultPasswordLength = 24
+	minPasswordLength     = 12
+	maxPasswordLength     = 128
+)
+
+// generateRootPassword returns a password with the configured length and character set.
+func generateRootPassword(length types.Int64, special types.Bool) (string, error) {
+	n := int64(defaultPasswordLength)
+	if !length.IsNull() {
+		n = length.ValueInt64()
+	}
+	return helper.GeneratePassword(int(n), special.IsNull() || special.ValueBool())
+}
+
+// planRootPassword keeps the password of an existing instance when root_password is not configured,
+// a new instance gets a generated password when it is created. Instances without password auth have no root password.
+func planRootPassword(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configured types.String
+	var passwordAuth types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("root_password"), &configured)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("password_auth"), &passwordAuth)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() || passwordAuth.IsUnknown() {
+		return
+	}
+
+	if !passwordAuthEnabled(passwordAuth) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("root_password"), types.StringNull())...)
+		return
+	}
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// a changed password auth replaces the instance, which then gets a new password
+	var current types.String
+	var currentPasswordAuth types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("root_password"), &current)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("password_auth"), &currentPasswordAuth)...)
+	if resp.Diagnostics.HasError() || passwordAuthEnabled(currentPasswordAuth) != passwordAuthEnabled(passwordAuth) {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("root_password"), current)...)
+}
+
+// passwordAuthEnabled returns whether an instance has password auth, it is enabled by default.
+func passwordAuthEnabled(passwordAuth types.Bool) bool {
+	return passwordAuth.IsNull() || passwordAuth.ValueBool()
+}
+
+// requiresReplaceRootPasswordChange replaces the instance when the configured root password changes, unless it is rebuilt.
+// Removing root_password from the configuration keeps the current password.
+func requiresReplaceRootPasswordChange(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.ConfigValue.IsNull() || req.StateValue.IsNull() {
+		return
+	}
+	requiresReplaceWithoutRebuild(ctx, req, resp)
+}
+
+var _ resource.ConfigValidator = cloudInstancePasswordAuthValidator{}
+
+// cloudInstancePasswordAuthValidator requires ssh keys and no root password when password auth is disabled.
+type cloudInstancePasswordAuthValidator struct{}
+
+func (v cloudInstancePasswordAuthValidator) Description(_ context.Context) string {
+	return "sshkeys must be set and root_password must not be set when password_auth is false"
+}
+
+func (v cloudInstancePasswordAuthValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cloudInstancePasswordAuthValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var passwordAuth types.Bool
+	var rootPassword, sshkeys types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_auth"), &passwordAuth)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("root_password"), &rootPassword)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sshkeys"), &sshkeys)...)
+	if resp.Diagnostics.HasError() || passwordAuth.IsUnknown() || passwordAuthEnabled(passwordAuth) {
+		return
+	}
+
+	if !rootPassword.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("root_password"),
+			"Invalid root_password",
+			"root_password can not be set when password_auth is false",
+		)
+	}
+	if sshkeys.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sshkeys"),
+			"Missing sshkeys",
+			"sshkeys must be set when password_auth is false, the instance can only be accessed with ssh keys",
+		)
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/uthoplatforms/terraform-provider-utho/helper"
+)
+
+func TestGenerateRootPassword(t *testing.T) {
+	for name, tc := range map[string]struct {
+		length  types.Int64
+		special types.Bool
+		want    int
+	}{
+		"default":    {length: types.Int64Null(), special: types.BoolNull(), want: defaultPasswordLength},
+		"length":     {length: types.Int64Value(minPasswordLength), special: types.BoolNull(), want: minPasswordLength},
+		"no special": {length: types.Int64Value(32), special: types.BoolValue(false), want: 32},
+	} {
+		t.Run(name, func(t *testing.T) {
+			password, err := generateRootPassword(tc.length, tc.special)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(password) != tc.want {
+				t.Errorf("password length = %d, want %d", len(password), tc.want)
+			}
+
+			classes := []string{helper.PasswordLowercase, helper.PasswordUppercase, helper.PasswordDigits}
+			if !tc.special.Equal(types.BoolValue(false)) {
+				classes = append(classes, helper.PasswordSpecial)
+			} else if strings.ContainsAny(password, helper.PasswordSpecial) {
+				t.Errorf("password %q has special characters", password)
+			}
+			for _, class := range classes {
+				if !strings.ContainsAny(password, class) {
+					t.Errorf("password %q has no character of %q", password, class)
+				}
+			}
+		})
+	}
+
+	first, _ := generateRootPassword(types.Int64Null(), types.BoolNull())
+	second, _ := generateRootPassword(types.Int64Null(), types.BoolNull())
+	if first == second {
+		t.Error("generated passwords should differ")
+	}
+}
+
+func TestCloudInstancePasswordAuthValidator(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewCloudInstanceResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	for name, tc := range map[string]struct {
+		passwordAuth          any
+		rootPassword, sshkeys any
+		expectError           bool
+	}{
+		"generated password":      {},
+		"configured password":     {rootPassword: "Secret-password-1"},
+		"key only":                {passwordAuth: false, sshkeys: "432"},
+		"key only with password":  {passwordAuth: false, rootPassword: "Secret-password-1", sshkeys: "432", expectError: true},
+		"key only without sshkey": {passwordAuth: false, expectError: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: testObject(schemaResp.Schema, map[string]tftypes.Value{
+				"password_auth": tftypes.NewValue(tftypes.Bool, tc.passwordAuth),
+				"root_password": tftypes.NewValue(tftypes.String, tc.rootPassword),
+				"sshkeys":       tftypes.NewValue(tftypes.String, tc.sshkeys),
+			})}
+
+			resp := &fwresource.ValidateConfigResponse{}
+			cloudInstancePasswordAuthValidator{}.ValidateResource(ctx, fwresource.ValidateConfigRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Errorf("expected error %t, got %v", tc.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
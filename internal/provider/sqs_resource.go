@@ -19,6 +19,7 @@ var (
 	_ resource.Resource                = &SqsResource{}
 	_ resource.ResourceWithConfigure   = &SqsResource{}
 	_ resource.ResourceWithImportState = &SqsResource{}
+	_ resource.ResourceWithModifyPlan  = &SqsResource{}
 )
 
 // NewSqsResource is a helper function to simplify the provider implementation.
@@ -92,6 +93,11 @@ func (s *SqsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 	}
 }
 
+// ModifyPlan validates the planned values against the utho api.
+func (s *SqsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validateDcslugPlan(ctx, s.client, req, resp)
+}
+
 // Import using sqs as the attribute
 func (s *SqsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// stateStringConverter converts a string value stored in a previous schema version to its new value.
+type stateStringConverter func(string) (any, error)
+
+// stringToInt64 converts a numeric string to a number, empty strings become null.
+func stringToInt64(s string) (any, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	return strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+}
+
+// upgradeRawState rewrites string attributes of a raw json state using the given converters.
+// Converters are keyed by attribute path, nested list elements are addressed with "*",
+// eg: "targets.*.backend_port".
+func upgradeRawState(req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse, converters map[string]stateStringConverter) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError(
+			"Unable to upgrade resource state",
+			"The previous resource state is not available as json. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var rawState map[string]any
+	if err := json.Unmarshal(req.RawState.JSON, &rawState); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to upgrade resource state",
+			"Could not parse previous resource state: "+err.Error(),
+		)
+		return
+	}
+
+	for attributePath, converter := range converters {
+		if err := convertStateValue(rawState, strings.Split(attributePath, "."), converter); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to upgrade resource state",
+				fmt.Sprintf("Could not convert %s: %s", attributePath, err.Error()),
+			)
+			return
+		}
+	}
+
+	upgradedState, err := json.Marshal(rawState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to upgrade resource state",
+			"Could not encode upgraded resource state: "+err.Error(),
+		)
+		return
+	}
+
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgradedState}
+}
+
+// clearRawState sets top level attributes of an upgraded state to null,
+// eg: computed lists that became optional and would otherwise be treated as configured.
+func clearRawState(resp *resource.UpgradeStateResponse, attributes ...string) {
+	if resp.Diagnostics.HasError() || resp.DynamicValue == nil {
+		return
+	}
+
+	var rawState map[string]any
+	if err := json.Unmarshal(resp.DynamicValue.JSON, &rawState); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to upgrade resource state",
+			"Could not parse upgraded resource state: "+err.Error(),
+		)
+		return
+	}
+	for _, attribute := range attributes {
+		rawState[attribute] = nil
+	}
+
+	upgradedState, err := json.Marshal(rawState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to upgrade resource state",
+			"Could not encode upgraded resource state: "+err.Error(),
+		)
+		return
+	}
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgradedState}
+}
+
+func convertStateValue(object map[string]any, attributePath []string, converter stateStringConverter) error {
+	value, ok := object[attributePath[0]]
+	if !ok || value == nil {
+		return nil
+	}
+
+	if len(attributePath) == 1 {
+		s, ok := value.(string)
+		if !ok {
+			return nil
+		}
+		converted, err := converter(s)
+		if err != nil {
+			return err
+		}
+		object[attributePath[0]] = converted
+		return nil
+	}
+
+	if attributePath[1] == "*" {
+		elements, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+		for _, element := range elements {
+			nested, ok := element.(map[string]any)
+			if !ok {
+				continue
+			}
+			if err := convertStateValue(nested, attributePath[2:], converter); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	nested, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	return convertStateValue(nested, attributePath[1:], converter)
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestUpgradeRawState(t *testing.T) {
+	rawState := `{
+		"id": "1234",
+		"port": "80",
+		"health_check_timeout": "",
+		"targets": [
+			{"ip": "103.146.242.55", "backend_port": "8080"},
+			{"ip": "103.146.200.55", "backend_port": "443"}
+		],
+		"policies": [{"period": "5m"}, {"period": "1h"}]
+	}`
+
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(rawState)}}
+	resp := &resource.UpgradeStateResponse{}
+	upgradeRawState(req, resp, map[string]stateStringConverter{
+		"port":                   stringToInt64,
+		"health_check_timeout":   stringToInt64,
+		"targets.*.backend_port": stringToInt64,
+		"policies.*.period": func(period string) (any, error) {
+			return parsePolicyPeriod(period), nil
+		},
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(resp.DynamicValue.JSON, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"id":                   "1234",
+		"port":                 float64(80),
+		"health_check_timeout": nil,
+		"targets": []any{
+			map[string]any{"ip": "103.146.242.55", "backend_port": float64(8080)},
+			map[string]any{"ip": "103.146.200.55", "backend_port": float64(443)},
+		},
+		"policies": []any{
+			map[string]any{"period": float64(5)},
+			map[string]any{"period": float64(60)},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("upgraded state = %v, want %v", got, want)
+	}
+}
+
+func TestUpgradeRawStateInvalidNumber(t *testing.T) {
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(`{"port": "http"}`)}}
+	resp := &resource.UpgradeStateResponse{}
+	upgradeRawState(req, resp, map[string]stateStringConverter{"port": stringToInt64})
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error for a non numeric port")
+	}
+}
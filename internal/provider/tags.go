@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// Resources are tagged with their tags merged over the provider default_tags.
+// The api stores tags as a comma separated list of key:value labels.
+
+// tagKeyRegex and tagValueRegex match the keys and values the label list can hold, labels are split on , and keys on
+// the first : and both are trimmed. Keys must not be empty.
+var (
+	tagKeyRegex   = regexp.MustCompile(`^[^,:\s]([^,:]*[^,:\s])?$`)
+	tagValueRegex = regexp.MustCompile(`^([^,\s]([^,]*[^,\s])?)?$`)
+)
+
+// tagsValidators returns the validators of a tags map.
+func tagsValidators() []validator.Map {
+	return []validator.Map{
+		mapvalidator.KeysAre(stringvalidator.RegexMatches(tagKeyRegex, "must not be empty, contain , or : or start or end with whitespace")),
+		mapvalidator.ValueStringsAre(stringvalidator.RegexMatches(tagValueRegex, "must not contain , or start or end with whitespace")),
+	}
+}
+
+// tagsAttribute returns the tags attribute of a taggable resource.
+func tagsAttribute() schema.MapAttribute {
+	return schema.MapAttribute{Optional: true, ElementType: types.StringType,
+		Description: "Tags of the resource. Tags with the same key as a provider default_tags tag override it. Keys can not contain , or : and values can not contain ,",
+		Validators:  tagsValidators(),
+	}
+}
+
+// tagsAllAttribute returns the tags_all attribute of a taggable resource.
+func tagsAllAttribute() schema.MapAttribute {
+	return schema.MapAttribute{Computed: true, ElementType: types.StringType,
+		Description: "Tags of the resource including the provider default_tags",
+	}
+}
+
+// defaultTagsFor returns the provider default_tags.
+func defaultTagsFor(client utho.Client) map[string]string {
+	if pc, ok := client.(*providerClient); ok {
+		return pc.defaultTags
+	}
+	return nil
+}
+
+// mergeTags returns the default tags overridden by the resource tags.
+func mergeTags(defaults, tags map[string]string) map[string]string {
+	merged := maps.Clone(defaults)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+	maps.Copy(merged, tags)
+	return merged
+}
+
+// resourceTags returns the tags of the resource from all its tags, leaving out the default tags it does not override.
+// The tags stay null when none are configured.
+func resourceTags(all, defaults map[string]string, configured types.Map) map[string]string {
+	tags := map[string]string{}
+	for key, value := range all {
+		_, isConfigured := configured.Elements()[key]
+		if defaultValue, isDefault := defaults[key]; isConfigured || !isDefault || defaultValue != value {
+			tags[key] = value
+		}
+	}
+	if configured.IsNull() && len(tags) == 0 {
+		return nil
+	}
+	return tags
+}
+
+// encodeTags returns the tags as the api stores them, sorted by key.
+func encodeTags(tags map[string]string) string {
+	labels := make([]string, 0, len(tags))
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		labels = append(labels, key+":"+tags[key])
+	}
+	return strings.Join(labels, ",")
+}
+
+// decodeTags returns the tags stored by the api, labels without a value have an empty value.
+func decodeTags(labels string) map[string]string {
+	tags := map[string]string{}
+	for _, label := range strings.Split(labels, ",") {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(label, ":")
+		tags[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return tags
+}
+
+// planTagsAll sets the planned tags_all to the resource tags merged over the provider default_tags,
+// so changed default tags update the resource.
+func planTagsAll(ctx context.Context, client utho.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var tags types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if resp.Diagnostics.HasError() || tags.IsUnknown() {
+		return
+	}
+	configured := map[string]string{}
+	for key, value := range tags.Elements() {
+		s, ok := value.(types.String)
+		if !ok || s.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))...)
+			return
+		}
+		configured[key] = s.ValueString()
+	}
+
+	tagsAll, diags := types.MapValueFrom(ctx, types.StringType, mergeTags(defaultTagsFor(client), configured))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+}
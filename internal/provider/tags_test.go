@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEncodeTags(t *testing.T) {
+	tags := map[string]string{"team": "platform", "env": "prod", "owner": ""}
+	encoded := encodeTags(tags)
+	if encoded != "env:prod,owner:,team:platform" {
+		t.Errorf("encodeTags() = %q", encoded)
+	}
+	if decoded := decodeTags(encoded); !maps.Equal(decoded, tags) {
+		t.Errorf("decodeTags(%q) = %v, want %v", encoded, decoded, tags)
+	}
+	if decoded := decodeTags(" legacy , env:prod "); !maps.Equal(decoded, map[string]string{"legacy": "", "env": "prod"}) {
+		t.Errorf("decodeTags() = %v", decoded)
+	}
+	if decoded := decodeTags(""); len(decoded) != 0 {
+		t.Errorf("decodeTags(\"\") = %v, want no tags", decoded)
+	}
+}
+
+func TestResourceTags(t *testing.T) {
+	defaults := map[string]string{"team": "platform", "env": "prod"}
+	all := mergeTags(defaults, map[string]string{"env": "dev", "app": "web"})
+	if want := map[string]string{"team": "platform", "env": "dev", "app": "web"}; !maps.Equal(all, want) {
+		t.Fatalf("mergeTags() = %v, want %v", all, want)
+	}
+
+	configured := types.MapValueMust(types.StringType, map[string]attr.Value{"env": types.StringValue("dev"), "app": types.StringValue("web")})
+	if got, want := resourceTags(all, defaults, configured), map[string]string{"env": "dev", "app": "web"}; !maps.Equal(got, want) {
+		t.Errorf("resourceTags() = %v, want %v", got, want)
+	}
+	// a configured tag equal to its default is kept
+	configured = types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("platform")})
+	if got, want := resourceTags(defaults, defaults, configured), map[string]string{"team": "platform"}; !maps.Equal(got, want) {
+		t.Errorf("resourceTags() = %v, want %v", got, want)
+	}
+	if got := resourceTags(defaults, defaults, types.MapNull(types.StringType)); got != nil {
+		t.Errorf("resourceTags() = %v, want nil without configured tags", got)
+	}
+	// tags added outside of terraform are reported
+	if got := resourceTags(map[string]string{"manual": "1"}, defaults, types.MapNull(types.StringType)); !maps.Equal(got, map[string]string{"manual": "1"}) {
+		t.Errorf("resourceTags() = %v", got)
+	}
+}
+
+func TestPlanTagsAll(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewCloudInstanceResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: testObject(schemaResp.Schema, map[string]tftypes.Value{
+		"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"env": tftypes.NewValue(tftypes.String, "dev"),
+		}),
+	})}
+
+	client := &providerClient{defaultTags: map[string]string{"team": "platform", "env": "prod"}}
+	resp := &fwresource.ModifyPlanResponse{Plan: plan}
+	planTagsAll(ctx, client, fwresource.ModifyPlanRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var tagsAll map[string]string
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("tags_all"), &tagsAll)...)
+	if want := map[string]string{"team": "platform", "env": "dev"}; !maps.Equal(tagsAll, want) {
+		t.Errorf("tags_all = %v, want %v", tagsAll, want)
+	}
+}
+
+func TestTagsRegex(t *testing.T) {
+	for key, want := range map[string]bool{
+		"env":         true,
+		"cost center": true,
+		"":            false,
+		"a,b":         false,
+		"a:b":         false,
+		" env":        false,
+	} {
+		if got := tagKeyRegex.MatchString(key); got != want {
+			t.Errorf("tagKeyRegex.MatchString(%q) = %t, want %t", key, got, want)
+		}
+	}
+	for value, want := range map[string]bool{
+		"":         true,
+		"dev":      true,
+		"10:30":    true,
+		"dev,prod": false,
+		"dev ":     false,
+	} {
+		if got := tagValueRegex.MatchString(value); got != want {
+			t.Errorf("tagValueRegex.MatchString(%q) = %t, want %t", value, got, want)
+		}
+	}
+
+	tags := map[string]string{"env": "dev", "window": "10:30", "owner": ""}
+	if got := decodeTags(encodeTags(tags)); !maps.Equal(got, tags) {
+		t.Errorf("decodeTags(encodeTags(%v)) = %v", tags, got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccTargetGroupResource(t *testing.T) {
@@ -17,22 +18,22 @@ func TestAccTargetGroupResource(t *testing.T) {
 resource "utho_target_group" "example" {
 	name                  = "example-utho"
 	protocol              = "HTTP"
-	port                  = "12"
+	port                  = 12
 	health_check_path     = "1"
 	health_check_protocol = "HTTP"
-	health_check_timeout  = "1"
-	unhealthy_threshold   = "1"
-	health_check_interval = "1"
-	healthy_threshold     = "1"
+	health_check_timeout  = 1
+	unhealthy_threshold   = 1
+	health_check_interval = 1
+	healthy_threshold     = 1
 	targets = [
 		{
 			ip               = "103.146.242.55"
-			backend_port     = "12"
+			backend_port     = 12
 			backend_protocol = "HTTP"
 		},
 		{
 			ip               = "103.146.200.55"
-			backend_port     = "15"
+			backend_port     = 15
 			backend_protocol = "HTTPS"
 		}
 	]
@@ -54,6 +55,48 @@ resource "utho_target_group" "example" {
 					resource.TestCheckResourceAttrSet(resourceName, "targets.1.cloudid"),
 				),
 			},
+			// update health check settings and swap one target in place
+			{
+				Config: providerConfig + `
+resource "utho_target_group" "example" {
+	name                  = "example-utho"
+	protocol              = "HTTP"
+	port                  = 12
+	health_check_path     = "/health"
+	health_check_protocol = "HTTP"
+	health_check_timeout  = 5
+	unhealthy_threshold   = 3
+	health_check_interval = 30
+	healthy_threshold     = 2
+	targets = [
+		{
+			ip               = "103.146.242.55"
+			backend_port     = 12
+			backend_protocol = "HTTP"
+		},
+		{
+			ip               = "103.146.210.55"
+			backend_port     = 15
+			backend_protocol = "HTTPS"
+		}
+	]
+	}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "health_check_path", "/health"),
+					resource.TestCheckResourceAttr(resourceName, "health_check_interval", "30"),
+					resource.TestCheckResourceAttr(resourceName, "healthy_threshold", "2"),
+					resource.TestCheckResourceAttr(resourceName, "targets.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "targets.0.ip", "103.146.242.55"),
+					resource.TestCheckResourceAttr(resourceName, "targets.1.ip", "103.146.210.55"),
+					resource.TestCheckResourceAttrSet(resourceName, "targets.1.id"),
+				),
+			},
 			{
 				ResourceName:      resourceName,
 				ImportState:       true,
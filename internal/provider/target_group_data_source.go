@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// NewTargetGroupDataSource is a helper function to simplify the provider implementation.
+// The target group is looked up by id or by name.
+func NewTargetGroupDataSource() datasource.DataSource {
+	return &resourceDataSource{
+		resource:    NewTargetGroupResource,
+		idAttribute: "id",
+		lookup:      lookupTargetGroup,
+		dcslug:      false,
+	}
+}
+
+func lookupTargetGroup(client utho.Client, name, _ string) ([]string, error) {
+	targetGroups, err := client.TargetGroup().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, targetGroup := range targetGroups {
+		if targetGroup.Name == name {
+			ids = append(ids, targetGroup.ID)
+		}
+	}
+	return ids, nil
+}
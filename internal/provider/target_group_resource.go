@@ -4,22 +4,29 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/terraform-provider-utho/helper"
 	"github.com/uthoplatforms/utho-go/utho"
 )
 
 // implement resource interfaces.
 var (
-	_ resource.Resource                = &TargetGroupResource{}
-	_ resource.ResourceWithConfigure   = &TargetGroupResource{}
-	_ resource.ResourceWithImportState = &TargetGroupResource{}
+	_ resource.Resource                 = &TargetGroupResource{}
+	_ resource.ResourceWithConfigure    = &TargetGroupResource{}
+	_ resource.ResourceWithImportState  = &TargetGroupResource{}
+	_ resource.ResourceWithUpgradeState = &TargetGroupResource{}
 )
 
 // NewTargetGroupResource is a helper function to simplify the provider implementation.
@@ -35,14 +42,14 @@ type TargetGroupResource struct {
 type TargetGroupResourceModel struct {
 	ID                  types.String          `tfsdk:"id"`
 	Name                types.String          `tfsdk:"name"`
-	Port                types.String          `tfsdk:"port"`
+	Port                types.Int64           `tfsdk:"port"`
 	Protocol            types.String          `tfsdk:"protocol"`
 	HealthCheckPath     types.String          `tfsdk:"health_check_path"`
-	HealthCheckInterval types.String          `tfsdk:"health_check_interval"`
+	HealthCheckInterval types.Int64           `tfsdk:"health_check_interval"`
 	HealthCheckProtocol types.String          `tfsdk:"health_check_protocol"`
-	HealthCheckTimeout  types.String          `tfsdk:"health_check_timeout"`
-	HealthyThreshold    types.String          `tfsdk:"healthy_threshold"`
-	UnhealthyThreshold  types.String          `tfsdk:"unhealthy_threshold"`
+	HealthCheckTimeout  types.Int64           `tfsdk:"health_check_timeout"`
+	HealthyThreshold    types.Int64           `tfsdk:"healthy_threshold"`
+	UnhealthyThreshold  types.Int64           `tfsdk:"unhealthy_threshold"`
 	CreatedAt           types.String          `tfsdk:"created_at"`
 	UpdatedAt           types.String          `tfsdk:"updated_at"`
 	Targets             []TargetResourceModel `tfsdk:"targets"`
@@ -54,7 +61,7 @@ type TargetResourceModel struct {
 	Status              types.String `tfsdk:"status"`
 	ScalingGroupid      types.String `tfsdk:"scaling_groupid"`
 	KubernetesClusterid types.String `tfsdk:"kubernetes_clusterid"`
-	BackendPort         types.String `tfsdk:"backend_port"`
+	BackendPort         types.Int64  `tfsdk:"backend_port"`
 	BackendProtocol     types.String `tfsdk:"backend_protocol"`
 	TargetgroupID       types.String `tfsdk:"targetgroup_id"`
 	FrontendID          types.String `tfsdk:"frontend_id"`
@@ -87,28 +94,47 @@ func (d *TargetGroupResource) Configure(_ context.Context, req resource.Configur
 // Schema defines the schema for the resource.
 func (s *TargetGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"id":                    schema.StringAttribute{Computed: true, Description: "id"},
-			"name":                  schema.StringAttribute{Required: true, Description: "Provide Target Group name eg: my_group", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"protocol":              schema.StringAttribute{Required: true, Description: "Provide protocol eg: HTTP, HTTPS, TCP, UDP", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"port":                  schema.StringAttribute{Required: true, Description: "Provide the port according to protocol eg: 80", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"health_check_path":     schema.StringAttribute{Required: true, Description: "Provide health check path for the target group", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"health_check_protocol": schema.StringAttribute{Required: true, Description: "Provide health check protocol for the target group", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"health_check_timeout":  schema.StringAttribute{Required: true, Description: "Provide health check timeout for the target group", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"unhealthy_threshold":   schema.StringAttribute{Required: true, Description: "Provide unhealthy threshold for the target group", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"health_check_interval": schema.StringAttribute{Required: true, Description: "Provide health check interval for the target group", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"healthy_threshold":     schema.StringAttribute{Required: true, Description: "Provide healthy threshold for the target group", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"created_at":            schema.StringAttribute{Computed: true, Description: "created at"},
-			"updated_at":            schema.StringAttribute{Computed: true, Description: "updated at"},
+			"id":   schema.StringAttribute{Computed: true, Description: "id"},
+			"name": schema.StringAttribute{Required: true, Description: "Provide Target Group name eg: my_group", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"protocol": schema.StringAttribute{Required: true, Description: "Provide protocol eg: HTTP, HTTPS, TCP, UDP", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators: []validator.String{stringvalidator.OneOf("HTTP", "HTTPS", "TCP", "UDP")},
+			},
+			"port": schema.Int64Attribute{Required: true, Description: "Provide the port according to protocol eg: 80", PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+				Validators: []validator.Int64{int64validator.Between(1, 65535)},
+			},
+			"health_check_path": schema.StringAttribute{Required: true, Description: "Provide health check path for the target group"},
+			"health_check_protocol": schema.StringAttribute{Required: true, Description: "Provide health check protocol for the target group eg: HTTP, HTTPS, TCP",
+				Validators: []validator.String{stringvalidator.OneOf("HTTP", "HTTPS", "TCP")},
+			},
+			"health_check_timeout": schema.Int64Attribute{Required: true, Description: "Provide health check timeout in seconds for the target group",
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"unhealthy_threshold": schema.Int64Attribute{Required: true, Description: "Provide unhealthy threshold for the target group",
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"health_check_interval": schema.Int64Attribute{Required: true, Description: "Provide health check interval in seconds for the target group",
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"healthy_threshold": schema.Int64Attribute{Required: true, Description: "Provide healthy threshold for the target group",
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"created_at": schema.StringAttribute{Computed: true, Description: "created at"},
+			"updated_at": schema.StringAttribute{Computed: true, Description: "updated at"},
 			"targets": schema.ListNestedAttribute{
 				Optional:    true,
-				Description: "targets",
+				Description: "Targets registered with the target group. Leave unset when targets are managed with utho_target_group_target",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"id":                   schema.StringAttribute{Computed: true, Description: "Id"},
-						"ip":                   schema.StringAttribute{Required: true, Description: "Target Ip", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-						"backend_port":         schema.StringAttribute{Required: true, Description: "Backend Port", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-						"backend_protocol":     schema.StringAttribute{Required: true, Description: "Backend Protocol", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+						"id": schema.StringAttribute{Computed: true, Description: "Id"},
+						"ip": schema.StringAttribute{Required: true, Description: "Target Ip"},
+						"backend_port": schema.Int64Attribute{Required: true, Description: "Backend Port",
+							Validators: []validator.Int64{int64validator.Between(1, 65535)},
+						},
+						"backend_protocol": schema.StringAttribute{Required: true, Description: "Backend Protocol",
+							Validators: []validator.String{stringvalidator.OneOf("HTTP", "HTTPS", "TCP", "UDP")},
+						},
 						"lbid":                 schema.StringAttribute{Computed: true, Description: "Lbid"},
 						"cloudid":              schema.StringAttribute{Computed: true, Description: "Cloudid"},
 						"status":               schema.StringAttribute{Computed: true, Description: "Status"},
@@ -123,6 +149,24 @@ func (s *TargetGroupResource) Schema(_ context.Context, _ resource.SchemaRequest
 	}
 }
 
+// UpgradeState migrates the numeric attributes that were stored as strings before version 1.
+func (s *TargetGroupResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeRawState(req, resp, map[string]stateStringConverter{
+					"port":                   stringToInt64,
+					"health_check_interval":  stringToInt64,
+					"health_check_timeout":   stringToInt64,
+					"healthy_threshold":      stringToInt64,
+					"unhealthy_threshold":    stringToInt64,
+					"targets.*.backend_port": stringToInt64,
+				})
+			},
+		},
+	}
+}
+
 // Import using target group as the attribute
 func (s *TargetGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
@@ -143,13 +187,13 @@ func (s *TargetGroupResource) Create(ctx context.Context, req resource.CreateReq
 	targetGroupRequest := utho.CreateTargetGroupParams{
 		Name:                plan.Name.ValueString(),
 		Protocol:            plan.Protocol.ValueString(),
-		Port:                plan.Port.ValueString(),
+		Port:                strconv.FormatInt(plan.Port.ValueInt64(), 10),
 		HealthCheckPath:     plan.HealthCheckPath.ValueString(),
 		HealthCheckProtocol: plan.HealthCheckProtocol.ValueString(),
-		HealthCheckInterval: plan.HealthCheckInterval.ValueString(),
-		HealthCheckTimeout:  plan.HealthCheckTimeout.ValueString(),
-		HealthyThreshold:    plan.HealthyThreshold.ValueString(),
-		UnhealthyThreshold:  plan.UnhealthyThreshold.ValueString(),
+		HealthCheckInterval: strconv.FormatInt(plan.HealthCheckInterval.ValueInt64(), 10),
+		HealthCheckTimeout:  strconv.FormatInt(plan.HealthCheckTimeout.ValueInt64(), 10),
+		HealthyThreshold:    strconv.FormatInt(plan.HealthyThreshold.ValueInt64(), 10),
+		UnhealthyThreshold:  strconv.FormatInt(plan.UnhealthyThreshold.ValueInt64(), 10),
 	}
 	tflog.Debug(ctx, "send create target group request")
 	createTargetGroupResponse, err := s.client.TargetGroup().Create(targetGroupRequest)
@@ -162,16 +206,15 @@ func (s *TargetGroupResource) Create(ctx context.Context, req resource.CreateReq
 	}
 	targetGroupId := strconv.Itoa(createTargetGroupResponse.ID)
 
-	var targets []string
 	for _, target := range plan.Targets {
 		createTargetGroupTargetParams := utho.CreateTargetGroupTargetParams{
 			TargetGroupId:   targetGroupId,
 			IP:              target.IP.ValueString(),
-			BackendPort:     target.BackendPort.ValueString(),
+			BackendPort:     strconv.FormatInt(target.BackendPort.ValueInt64(), 10),
 			BackendProtocol: target.BackendProtocol.ValueString(),
 			Cloudid:         target.Cloudid.ValueString(),
 		}
-		createTargetsRes, err := s.client.TargetGroup().CreateTarget(createTargetGroupTargetParams)
+		_, err := s.client.TargetGroup().CreateTarget(createTargetGroupTargetParams)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error creating target group",
@@ -179,7 +222,6 @@ func (s *TargetGroupResource) Create(ctx context.Context, req resource.CreateReq
 			)
 			return
 		}
-		targets = append(targets, createTargetsRes.ID)
 	}
 
 	targetGroup, err := s.client.TargetGroup().Read(targetGroupId)
@@ -193,34 +235,21 @@ func (s *TargetGroupResource) Create(ctx context.Context, req resource.CreateReq
 
 	// Map response body to schema and populate Computed attribute values
 	var targetsResourceModel []TargetResourceModel
-	for _, target := range targetGroup.Targets {
-		targetResourceModel := TargetResourceModel{
-			Lbid:                types.StringValue(target.Lbid),
-			IP:                  types.StringValue(target.IP),
-			Cloudid:             types.StringValue(target.Cloudid),
-			Status:              types.StringValue(target.Status),
-			ScalingGroupid:      types.StringValue(target.ScalingGroupid),
-			KubernetesClusterid: types.StringValue(target.KubernetesClusterid),
-			BackendPort:         types.StringValue(target.BackendPort),
-			BackendProtocol:     types.StringValue(target.BackendProtocol),
-			TargetgroupID:       types.StringValue(target.TargetgroupID),
-			FrontendID:          types.StringValue(target.FrontendID),
-			ID:                  types.StringValue(target.ID),
-		}
-		targetsResourceModel = append(targetsResourceModel, targetResourceModel)
+	if plan.Targets != nil {
+		targetsResourceModel = targetGroupTargetsModel(plan.Targets, targetGroup.Targets)
 	}
 
 	plan = TargetGroupResourceModel{
 		ID:                  types.StringValue(targetGroupId),
 		Name:                types.StringValue(targetGroup.Name),
-		Port:                types.StringValue(targetGroup.Port),
+		Port:                types.Int64Value(helper.ParseInt64(targetGroup.Port)),
 		Protocol:            types.StringValue(targetGroup.Protocol),
 		HealthCheckPath:     types.StringValue(targetGroup.HealthCheckPath),
-		HealthCheckInterval: types.StringValue(targetGroup.HealthCheckInterval),
+		HealthCheckInterval: types.Int64Value(helper.ParseInt64(targetGroup.HealthCheckInterval)),
 		HealthCheckProtocol: types.StringValue(targetGroup.HealthCheckProtocol),
-		HealthCheckTimeout:  types.StringValue(targetGroup.HealthCheckTimeout),
-		HealthyThreshold:    types.StringValue(targetGroup.HealthyThreshold),
-		UnhealthyThreshold:  types.StringValue(targetGroup.UnhealthyThreshold),
+		HealthCheckTimeout:  types.Int64Value(helper.ParseInt64(targetGroup.HealthCheckTimeout)),
+		HealthyThreshold:    types.Int64Value(helper.ParseInt64(targetGroup.HealthyThreshold)),
+		UnhealthyThreshold:  types.Int64Value(helper.ParseInt64(targetGroup.UnhealthyThreshold)),
 		CreatedAt:           types.StringValue(targetGroup.CreatedAt),
 		UpdatedAt:           types.StringValue(targetGroup.UpdatedAt),
 		Targets:             targetsResourceModel,
@@ -259,35 +288,24 @@ func (s *TargetGroupResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	// Overwrite items with refreshed state
+	// targets are only tracked when they are managed inline or the group is being imported,
+	// so targets registered with utho_target_group_target do not show up as a diff
 	var targetsResourceModel []TargetResourceModel
-	for _, target := range targetGroup.Targets {
-		targetResourceModel := TargetResourceModel{
-			Lbid:                types.StringValue(target.Lbid),
-			IP:                  types.StringValue(target.IP),
-			Cloudid:             types.StringValue(target.Cloudid),
-			Status:              types.StringValue(target.Status),
-			ScalingGroupid:      types.StringValue(target.ScalingGroupid),
-			KubernetesClusterid: types.StringValue(target.KubernetesClusterid),
-			BackendPort:         types.StringValue(target.BackendPort),
-			BackendProtocol:     types.StringValue(target.BackendProtocol),
-			TargetgroupID:       types.StringValue(target.TargetgroupID),
-			FrontendID:          types.StringValue(target.FrontendID),
-			ID:                  types.StringValue(target.ID),
-		}
-		targetsResourceModel = append(targetsResourceModel, targetResourceModel)
+	if state.Targets != nil || state.Name.IsNull() {
+		targetsResourceModel = targetGroupTargetsModel(state.Targets, targetGroup.Targets)
 	}
 
 	state = TargetGroupResourceModel{
 		ID:                  types.StringValue(targetGroup.ID),
 		Name:                types.StringValue(targetGroup.Name),
-		Port:                types.StringValue(targetGroup.Port),
+		Port:                types.Int64Value(helper.ParseInt64(targetGroup.Port)),
 		Protocol:            types.StringValue(targetGroup.Protocol),
 		HealthCheckPath:     types.StringValue(targetGroup.HealthCheckPath),
-		HealthCheckInterval: types.StringValue(targetGroup.HealthCheckInterval),
+		HealthCheckInterval: types.Int64Value(helper.ParseInt64(targetGroup.HealthCheckInterval)),
 		HealthCheckProtocol: types.StringValue(targetGroup.HealthCheckProtocol),
-		HealthCheckTimeout:  types.StringValue(targetGroup.HealthCheckTimeout),
-		HealthyThreshold:    types.StringValue(targetGroup.HealthyThreshold),
-		UnhealthyThreshold:  types.StringValue(targetGroup.UnhealthyThreshold),
+		HealthCheckTimeout:  types.Int64Value(helper.ParseInt64(targetGroup.HealthCheckTimeout)),
+		HealthyThreshold:    types.Int64Value(helper.ParseInt64(targetGroup.HealthyThreshold)),
+		UnhealthyThreshold:  types.Int64Value(helper.ParseInt64(targetGroup.UnhealthyThreshold)),
 		CreatedAt:           types.StringValue(targetGroup.CreatedAt),
 		UpdatedAt:           types.StringValue(targetGroup.UpdatedAt),
 		Targets:             targetsResourceModel,
@@ -302,8 +320,127 @@ func (s *TargetGroupResource) Read(ctx context.Context, req resource.ReadRequest
 	tflog.Debug(ctx, "finish get target group request")
 }
 
+// Update updates the health check settings in place and only adds or removes the targets that changed.
 func (s *TargetGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// updating resource is not supported
+	tflog.Debug(ctx, "update target group")
+	var plan TargetGroupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state TargetGroupResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	targetGroupId := state.ID.ValueString()
+
+	if !plan.HealthCheckPath.Equal(state.HealthCheckPath) ||
+		!plan.HealthCheckProtocol.Equal(state.HealthCheckProtocol) ||
+		!plan.HealthCheckInterval.Equal(state.HealthCheckInterval) ||
+		!plan.HealthCheckTimeout.Equal(state.HealthCheckTimeout) ||
+		!plan.HealthyThreshold.Equal(state.HealthyThreshold) ||
+		!plan.UnhealthyThreshold.Equal(state.UnhealthyThreshold) {
+		updateTargetGroupParams := utho.UpdateTargetGroupParams{
+			TargetGroupId:       targetGroupId,
+			Name:                plan.Name.ValueString(),
+			Protocol:            plan.Protocol.ValueString(),
+			Port:                strconv.FormatInt(plan.Port.ValueInt64(), 10),
+			HealthCheckPath:     plan.HealthCheckPath.ValueString(),
+			HealthCheckProtocol: plan.HealthCheckProtocol.ValueString(),
+			HealthCheckInterval: strconv.FormatInt(plan.HealthCheckInterval.ValueInt64(), 10),
+			HealthCheckTimeout:  strconv.FormatInt(plan.HealthCheckTimeout.ValueInt64(), 10),
+			HealthyThreshold:    strconv.FormatInt(plan.HealthyThreshold.ValueInt64(), 10),
+			UnhealthyThreshold:  strconv.FormatInt(plan.UnhealthyThreshold.ValueInt64(), 10),
+		}
+		tflog.Debug(ctx, "send update target group request")
+		_, err := s.client.TargetGroup().Update(updateTargetGroupParams)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating utho target group",
+				"Could not update utho target group "+targetGroupId+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	stateTargets := map[string]TargetResourceModel{}
+	for _, target := range state.Targets {
+		stateTargets[targetKey(target.IP.ValueString(), target.BackendPort.ValueInt64(), target.BackendProtocol.ValueString())] = target
+	}
+	planTargets := map[string]TargetResourceModel{}
+	for _, target := range plan.Targets {
+		planTargets[targetKey(target.IP.ValueString(), target.BackendPort.ValueInt64(), target.BackendProtocol.ValueString())] = target
+	}
+
+	// add new targets before removing old ones so the group keeps serving traffic
+	for key, target := range planTargets {
+		if _, ok := stateTargets[key]; ok {
+			continue
+		}
+		createTargetGroupTargetParams := utho.CreateTargetGroupTargetParams{
+			TargetGroupId:   targetGroupId,
+			IP:              target.IP.ValueString(),
+			BackendPort:     strconv.FormatInt(target.BackendPort.ValueInt64(), 10),
+			BackendProtocol: target.BackendProtocol.ValueString(),
+		}
+		tflog.Debug(ctx, "send create target group target request")
+		_, err := s.client.TargetGroup().CreateTarget(createTargetGroupTargetParams)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating utho target group",
+				"Could not create target group target "+target.IP.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+	for key, target := range stateTargets {
+		if _, ok := planTargets[key]; ok {
+			continue
+		}
+		tflog.Debug(ctx, "send delete target group target request")
+		_, err := s.client.TargetGroup().DeleteTarget(targetGroupId, target.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating utho target group",
+				"Could not delete target group target "+target.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "send get target group request")
+	targetGroup, err := s.client.TargetGroup().Read(targetGroupId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho target group",
+			"Could not read utho target group "+targetGroupId+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(targetGroup.ID)
+	plan.HealthCheckPath = types.StringValue(targetGroup.HealthCheckPath)
+	plan.HealthCheckInterval = types.Int64Value(helper.ParseInt64(targetGroup.HealthCheckInterval))
+	plan.HealthCheckProtocol = types.StringValue(targetGroup.HealthCheckProtocol)
+	plan.HealthCheckTimeout = types.Int64Value(helper.ParseInt64(targetGroup.HealthCheckTimeout))
+	plan.HealthyThreshold = types.Int64Value(helper.ParseInt64(targetGroup.HealthyThreshold))
+	plan.UnhealthyThreshold = types.Int64Value(helper.ParseInt64(targetGroup.UnhealthyThreshold))
+	plan.CreatedAt = types.StringValue(targetGroup.CreatedAt)
+	plan.UpdatedAt = types.StringValue(targetGroup.UpdatedAt)
+	if plan.Targets != nil {
+		plan.Targets = targetGroupTargetsModel(plan.Targets, targetGroup.Targets)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish update target group")
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -327,3 +464,57 @@ func (s *TargetGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 }
+
+// targetKey identifies a target by its address and backend settings, since the target id
+// is only known once the target has been registered.
+func targetKey(ip string, backendPort int64, backendProtocol string) string {
+	return fmt.Sprintf("%s:%d/%s", ip, backendPort, strings.ToUpper(backendProtocol))
+}
+
+// targetGroupTargetsModel maps the targets returned by the api to the schema, keeping the
+// order of the given targets so that a reordered api response does not show up as a diff.
+// Targets that are unknown to the given list are appended at the end.
+func targetGroupTargetsModel(ordered []TargetResourceModel, targets []utho.Target) []TargetResourceModel {
+	apiTargets := map[string]utho.Target{}
+	for _, target := range targets {
+		apiTargets[targetKey(target.IP, helper.ParseInt64(target.BackendPort), target.BackendProtocol)] = target
+	}
+
+	var keys []string
+	seen := map[string]bool{}
+	for _, target := range ordered {
+		key := targetKey(target.IP.ValueString(), target.BackendPort.ValueInt64(), target.BackendProtocol.ValueString())
+		if _, ok := apiTargets[key]; ok && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	for _, target := range targets {
+		key := targetKey(target.IP, helper.ParseInt64(target.BackendPort), target.BackendProtocol)
+		if !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+
+	var targetsResourceModel []TargetResourceModel
+	for _, key := range keys {
+		target := apiTargets[key]
+		targetResourceModel := TargetResourceModel{
+			Lbid:                types.StringValue(target.Lbid),
+			IP:                  types.StringValue(target.IP),
+			Cloudid:             types.StringValue(target.Cloudid),
+			Status:              types.StringValue(target.Status),
+			ScalingGroupid:      types.StringValue(target.ScalingGroupid),
+			KubernetesClusterid: types.StringValue(target.KubernetesClusterid),
+			BackendPort:         types.Int64Value(helper.ParseInt64(target.BackendPort)),
+			BackendProtocol:     types.StringValue(target.BackendProtocol),
+			TargetgroupID:       types.StringValue(target.TargetgroupID),
+			FrontendID:          types.StringValue(target.FrontendID),
+			ID:                  types.StringValue(target.ID),
+		}
+		targetsResourceModel = append(targetsResourceModel, targetResourceModel)
+	}
+
+	return targetsResourceModel
+}
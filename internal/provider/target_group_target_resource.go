@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/terraform-provider-utho/helper"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// implement resource interfaces.
+var (
+	_ resource.Resource                     = &TargetGroupTargetResource{}
+	_ resource.ResourceWithConfigure        = &TargetGroupTargetResource{}
+	_ resource.ResourceWithImportState      = &TargetGroupTargetResource{}
+	_ resource.ResourceWithConfigValidators = &TargetGroupTargetResource{}
+)
+
+// NewTargetGroupTargetResource is a helper function to simplify the provider implementation.
+func NewTargetGroupTargetResource() resource.Resource {
+	return &TargetGroupTargetResource{}
+}
+
+// TargetGroupTargetResource is the resource implementation.
+type TargetGroupTargetResource struct {
+	client utho.Client
+}
+
+type TargetGroupTargetResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	TargetgroupID   types.String `tfsdk:"targetgroup_id"`
+	IP              types.String `tfsdk:"ip"`
+	CloudInstanceID types.String `tfsdk:"cloud_instance_id"`
+	BackendPort     types.Int64  `tfsdk:"backend_port"`
+	BackendProtocol types.String `tfsdk:"backend_protocol"`
+	Status          types.String `tfsdk:"status"`
+}
+
+// Metadata returns the resource type name.
+func (s *TargetGroupTargetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_target_group_target"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *TargetGroupTargetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(utho.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected TargetGroupTarget Data Source Configure Type",
+			fmt.Sprintf("Expected utho.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// Schema defines the schema for the resource.
+func (s *TargetGroupTargetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                schema.StringAttribute{Computed: true, Description: "id", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"targetgroup_id":    schema.StringAttribute{Required: true, Description: "Id of the target group to register the target with", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"ip":                schema.StringAttribute{Optional: true, Computed: true, Description: "Target Ip. Conflicts with cloud_instance_id", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace(), stringplanmodifier.UseStateForUnknown()}},
+			"cloud_instance_id": schema.StringAttribute{Optional: true, Description: "Id of a cloud instance to register by its private ip. Conflicts with ip", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"backend_port": schema.Int64Attribute{Required: true, Description: "Backend Port", PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+				Validators: []validator.Int64{int64validator.Between(1, 65535)},
+			},
+			"backend_protocol": schema.StringAttribute{Required: true, Description: "Backend Protocol", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators: []validator.String{stringvalidator.OneOf("HTTP", "HTTPS", "TCP", "UDP")},
+			},
+			"status": schema.StringAttribute{Computed: true, Description: "Status"},
+		},
+	}
+}
+
+// ConfigValidators requires the target to be given either by ip or by cloud instance.
+func (s *TargetGroupTargetResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("ip"),
+			path.MatchRoot("cloud_instance_id"),
+		),
+	}
+}
+
+// Import using targetgroup_id/target_id as the attribute
+func (s *TargetGroupTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "/")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: targetgroup_id/target_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("targetgroup_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+}
+
+// Create a new resource.
+func (s *TargetGroupTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "create target group target")
+	// Retrieve values from plan
+	var plan TargetGroupTargetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ip := plan.IP.ValueString()
+	if !plan.CloudInstanceID.IsNull() {
+		tflog.Debug(ctx, "send get cloud instance request")
+		cloudInstance, err := s.client.CloudInstances().Read(plan.CloudInstanceID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading utho cloud instance",
+				"Could not read utho cloud instance "+plan.CloudInstanceID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		ip = cloudInstancePrivateIP(cloudInstance)
+		if ip == "" {
+			resp.Diagnostics.AddError(
+				"Error creating target group target",
+				"Cloud instance "+plan.CloudInstanceID.ValueString()+" has no private ip to register with the target group",
+			)
+			return
+		}
+	}
+
+	// Generate API request body from plan
+	createTargetGroupTargetParams := utho.CreateTargetGroupTargetParams{
+		TargetGroupId:   plan.TargetgroupID.ValueString(),
+		IP:              ip,
+		BackendPort:     strconv.FormatInt(plan.BackendPort.ValueInt64(), 10),
+		BackendProtocol: plan.BackendProtocol.ValueString(),
+		Cloudid:         plan.CloudInstanceID.ValueString(),
+	}
+	tflog.Debug(ctx, "send create target group target request")
+	createTargetRes, err := s.client.TargetGroup().CreateTarget(createTargetGroupTargetParams)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating target group target",
+			"Could not create target group target, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	target, err := s.client.TargetGroup().ReadTarget(plan.TargetgroupID.ValueString(), createTargetRes.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho target group target",
+			"Could not read utho target group target "+createTargetRes.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = types.StringValue(target.ID)
+	plan.IP = types.StringValue(target.IP)
+	plan.Status = types.StringValue(target.Status)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish create target group target")
+}
+
+// Read resource information.
+func (s *TargetGroupTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "read target group target")
+
+	// Get current state
+	var state TargetGroupTargetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "send get target group target request")
+	// Get refreshed target value from utho
+	target, err := s.client.TargetGroup().ReadTarget(state.TargetgroupID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho target group target",
+			"Could not read utho target group target "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Overwrite items with refreshed state
+	// an imported target keeps the cloud instance it was registered with, targets registered by ip have no cloudid
+	if state.BackendProtocol.IsNull() && target.Cloudid != "" {
+		state.CloudInstanceID = types.StringValue(target.Cloudid)
+	}
+	state.ID = types.StringValue(target.ID)
+	state.TargetgroupID = types.StringValue(target.TargetgroupID)
+	state.IP = types.StringValue(target.IP)
+	state.BackendPort = types.Int64Value(helper.ParseInt64(target.BackendPort))
+	state.BackendProtocol = types.StringValue(target.BackendProtocol)
+	state.Status = types.StringValue(target.Status)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish get target group target request")
+}
+
+func (s *TargetGroupTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// updating resource is not supported
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (s *TargetGroupTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "delete target group target")
+	// Get current state
+	var state TargetGroupTargetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "send delete target group target request")
+	// delete target group target
+	_, err := s.client.TargetGroup().DeleteTarget(state.TargetgroupID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleteing utho target group target",
+			"Could not delete utho target group target "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// cloudInstancePrivateIP returns the primary private ip of a cloud instance.
+func cloudInstancePrivateIP(cloudInstance *utho.CloudInstance) string {
+	for _, v := range cloudInstance.Networks.Private.V4 {
+		if v.Primary == "1" && v.IPAddress != "" {
+			return v.IPAddress
+		}
+	}
+	for _, v := range cloudInstance.Networks.Private.V4 {
+		if v.IPAddress != "" {
+			return v.IPAddress
+		}
+	}
+
+	return cloudInstance.V4Private.IPAddress
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccTargetGroupTargetResource(t *testing.T) {
+	resourceName := "utho_target_group_target.example"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "utho_target_group" "example" {
+	name                  = "example-utho"
+	protocol              = "HTTP"
+	port                  = 80
+	health_check_path     = "/"
+	health_check_protocol = "HTTP"
+	health_check_timeout  = 5
+	unhealthy_threshold   = 3
+	health_check_interval = 30
+	healthy_threshold     = 2
+}
+
+resource "utho_target_group_target" "example" {
+	targetgroup_id   = utho_target_group.example.id
+	ip               = "103.146.242.55"
+	backend_port     = 80
+	backend_protocol = "HTTP"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ip", "103.146.242.55"),
+					resource.TestCheckResourceAttr(resourceName, "backend_port", "80"),
+					resource.TestCheckResourceAttr(resourceName, "backend_protocol", "HTTP"),
+					resource.TestCheckResourceAttrPair(resourceName, "targetgroup_id", "utho_target_group.example", "id"),
+					resource.TestCheckNoResourceAttr("utho_target_group.example", "targets"),
+
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "status"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("not found: %s", resourceName)
+					}
+					return rs.Primary.Attributes["targetgroup_id"] + "/" + rs.Primary.ID, nil
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxUserDataSize is the largest cloud-init user data, in bytes after base64 decoding, accepted by cloud instances.
+const maxUserDataSize = 64 * 1024
+
+// gzipMagic starts gzip compressed user data.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// decodeUserData returns the user data script, user data can be given raw or base64 encoded.
+// Base64 encoded user data is a text script or a gzip compressed document.
+func decodeUserData(userData string) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(userData)
+	if err == nil && (utf8.Valid(decoded) || bytes.HasPrefix(decoded, gzipMagic)) {
+		return decoded
+	}
+	return []byte(userData)
+}
+
+// encodeUserData returns the user data base64 encoded, as the api expects it.
+func encodeUserData(userData string) string {
+	return base64.StdEncoding.EncodeToString(decodeUserData(userData))
+}
+
+// userDataHash returns the sha256 of the user data script, so raw and base64 encoded user data have the same hash.
+// The hash is empty without user data.
+func userDataHash(userData types.String) types.String {
+	if userData.IsUnknown() {
+		return types.StringUnknown()
+	}
+	if userData.IsNull() {
+		return types.StringValue("")
+	}
+	sum := sha256.Sum256(decodeUserData(userData.ValueString()))
+	return types.StringValue(hex.EncodeToString(sum[:]))
+}
+
+var _ validator.String = userDataValidator{}
+
+// userDataValidator checks that user data is not larger than maxUserDataSize.
+type userDataValidator struct{}
+
+func (v userDataValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("user data must be at most %d bytes", maxUserDataSize)
+}
+
+func (v userDataValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v userDataValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if size := len(decodeUserData(req.ConfigValue.ValueString())); size > maxUserDataSize {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"User data too large",
+			fmt.Sprintf("User data is %d bytes, cloud instances accept at most %d bytes", size, maxUserDataSize),
+		)
+	}
+}
+
+// requiresReplaceUserDataChange replaces the instance when the user data script changes,
+// re-encoding the same script does not. Imported instances have no user data hash and adopt the configured user data.
+func requiresReplaceUserDataChange(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var hash types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("user_data_hash"), &hash)...)
+	if resp.Diagnostics.HasError() || hash.IsNull() {
+		return
+	}
+	resp.RequiresReplace = !userDataHash(req.PlanValue).Equal(hash)
+}
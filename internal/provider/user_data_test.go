@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUserDataHash(t *testing.T) {
+	script := "#cloud-config\npackages: [nginx]\n"
+	raw := userDataHash(types.StringValue(script))
+	encoded := userDataHash(types.StringValue(base64.StdEncoding.EncodeToString([]byte(script))))
+	if !raw.Equal(encoded) {
+		t.Errorf("raw and base64 user data hashes differ: %s, %s", raw, encoded)
+	}
+	if raw.Equal(userDataHash(types.StringValue(script + "\n"))) {
+		t.Error("different user data should have different hashes")
+	}
+	if hash := userDataHash(types.StringNull()); hash.ValueString() != "" || hash.IsNull() {
+		t.Errorf("hash without user data = %s, want empty", hash)
+	}
+	if !userDataHash(types.StringUnknown()).IsUnknown() {
+		t.Error("hash of unknown user data should be unknown")
+	}
+}
+
+func TestEncodeUserData(t *testing.T) {
+	script := "#!/bin/sh\necho ok\n"
+	want := base64.StdEncoding.EncodeToString([]byte(script))
+	if got := encodeUserData(script); got != want {
+		t.Errorf("encodeUserData(raw) = %q, want %q", got, want)
+	}
+	if got := encodeUserData(want); got != want {
+		t.Errorf("encodeUserData(base64) = %q, want %q", got, want)
+	}
+
+	gzipped := base64.StdEncoding.EncodeToString(append(gzipMagic, 0xff, 0x00))
+	if got := encodeUserData(gzipped); got != gzipped {
+		t.Errorf("encodeUserData(gzip) = %q, want %q", got, gzipped)
+	}
+}
+
+func TestUserDataValidator(t *testing.T) {
+	for size, wantError := range map[int]bool{maxUserDataSize: false, maxUserDataSize + 1: true} {
+		req := validator.StringRequest{Path: path.Root("user_data"), ConfigValue: types.StringValue("#" + strings.Repeat("a", size-1))}
+		var resp validator.StringResponse
+		userDataValidator{}.ValidateString(context.Background(), req, &resp)
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("user data of %d bytes: error = %v, want %v", size, resp.Diagnostics.HasError(), wantError)
+		}
+	}
+}
@@ -0,0 +1,459 @@
+package provider
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// Requests for api fields and endpoints that are not covered by the utho-go client yet.
+// They use the client request helpers so authentication and error handling stay the same.
+
+// Auto Scaling Schedule
+type autoScalingScheduleParams struct {
+	Name        string `json:"name"`
+	Desiredsize string `json:"desiredsize"`
+	Recurrence  string `json:"recurrence"`
+	StartDate   string `json:"start_date"`
+	Timezone    string `json:"timezone,omitempty"`
+}
+
+// createAutoScalingSchedule is utho.AutoScalingService.CreateSchedule with timezone support.
+func createAutoScalingSchedule(client utho.Client, autoScalingId string, params autoScalingScheduleParams) (*utho.CreateResponse, error) {
+	reqUrl := "autoscaling/" + autoScalingId + "/schedulepolicy"
+	req, _ := client.NewRequest("POST", reqUrl, &params)
+
+	var schedule utho.CreateResponse
+	_, err := client.Do(req, &schedule)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.Status != "success" && schedule.Status != "" {
+		return nil, errors.New(schedule.Message)
+	}
+
+	return &schedule, nil
+}
+
+// updateAutoScalingSchedule is utho.AutoScalingService.UpdateSchedule with timezone support.
+func updateAutoScalingSchedule(client utho.Client, autoScalingId, scheduleId string, params autoScalingScheduleParams) (*utho.UpdateResponse, error) {
+	reqUrl := "autoscaling/" + autoScalingId + "/schedulepolicy/" + scheduleId
+	req, _ := client.NewRequest("PUT", reqUrl, &params)
+
+	var schedule utho.UpdateResponse
+	_, err := client.Do(req, &schedule)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.Status != "success" && schedule.Status != "" {
+		return nil, errors.New(schedule.Message)
+	}
+
+	return &schedule, nil
+}
+
+// Auto Scaling State
+// The suspend and resume actions are not part of the utho-go client yet.
+
+// suspendAutoScaling stops the group from scaling, running instances are kept.
+func suspendAutoScaling(client utho.Client, autoScalingId string) (*utho.BasicResponse, error) {
+	return autoScalingAction(client, autoScalingId, "suspend")
+}
+
+// resumeAutoScaling lets a suspended group scale again.
+func resumeAutoScaling(client utho.Client, autoScalingId string) (*utho.BasicResponse, error) {
+	return autoScalingAction(client, autoScalingId, "resume")
+}
+
+func autoScalingAction(client utho.Client, autoScalingId, action string) (*utho.BasicResponse, error) {
+	reqUrl := "autoscaling/" + autoScalingId + "/" + action
+	req, _ := client.NewRequest("POST", reqUrl)
+
+	var autoscaling utho.BasicResponse
+	_, err := client.Do(req, &autoscaling)
+	if err != nil {
+		return nil, err
+	}
+	if autoscaling.Status != "success" && autoscaling.Status != "" {
+		return nil, errors.New(autoscaling.Message)
+	}
+
+	return &autoscaling, nil
+}
+
+// Auto Scaling Update
+type updateAutoScalingParams struct {
+	utho.UpdateAutoScalingParams
+	Stackimage string `json:"stackimage,omitempty"`
+}
+
+// updateAutoScaling is utho.AutoScalingService.Update with stackimage support.
+func updateAutoScaling(client utho.Client, params updateAutoScalingParams) (*utho.UpdateResponse, error) {
+	reqUrl := "autoscaling/" + params.AutoScalingId
+	req, _ := client.NewRequest("PUT", reqUrl, &params)
+
+	var autoscaling utho.UpdateResponse
+	_, err := client.Do(req, &autoscaling)
+	if err != nil {
+		return nil, err
+	}
+	if autoscaling.Status != "success" && autoscaling.Status != "" {
+		return nil, errors.New(autoscaling.Message)
+	}
+
+	return &autoscaling, nil
+}
+
+// Cloud Plans
+// utho-go only lists object storage pricing, cloud instance plans use the same pricing api.
+type cloudPlan struct {
+	utho.Pricing
+	Plantype string `json:"plantype"`
+}
+
+type cloudPlanList struct {
+	Pricing []cloudPlan `json:"pricing"`
+	Status  string      `json:"status,omitempty"`
+	Message string      `json:"message,omitempty"`
+}
+
+// listCloudPlans returns the cloud instance plans, available in dcslug when it is not empty.
+func listCloudPlans(client utho.Client, dcslug string) ([]cloudPlan, error) {
+	reqUrl := "pricing/cloud"
+	if dcslug != "" {
+		reqUrl += "?dcslug=" + url.QueryEscape(dcslug)
+	}
+	req, _ := client.NewRequest("GET", reqUrl)
+
+	var planList cloudPlanList
+	_, err := client.Do(req, &planList)
+	if err != nil {
+		return nil, err
+	}
+	if planList.Status != "success" && planList.Status != "" {
+		return nil, errors.New(planList.Message)
+	}
+	if len(planList.Pricing) == 0 {
+		return []cloudPlan{}, nil
+	}
+	return planList.Pricing, nil
+}
+
+// Datacenters
+type datacenter struct {
+	Slug          string `json:"slug"`
+	Location      string `json:"location"`
+	Country       string `json:"country"`
+	Cc            string `json:"cc"`
+	Status        string `json:"status"`
+	Kubernetes    string `json:"k8s"`
+	ObjectStorage string `json:"objectstorage"`
+	Gpu           string `json:"gpu"`
+}
+
+type datacenterList struct {
+	Locations []datacenter `json:"locations"`
+	Status    string       `json:"status,omitempty"`
+	Message   string       `json:"message,omitempty"`
+}
+
+// listDatacenters returns the zones a cloud instance can be deployed in.
+func listDatacenters(client utho.Client) ([]datacenter, error) {
+	reqUrl := "cloud/availablezones"
+	req, _ := client.NewRequest("GET", reqUrl)
+
+	var locations datacenterList
+	_, err := client.Do(req, &locations)
+	if err != nil {
+		return nil, err
+	}
+	if locations.Status != "success" && locations.Status != "" {
+		return nil, errors.New(locations.Message)
+	}
+	if len(locations.Locations) == 0 {
+		return []datacenter{}, nil
+	}
+	return locations.Locations, nil
+}
+
+// Cloud Instance Deploy
+type createCloudInstanceParams struct {
+	utho.CreateCloudInstanceParams
+	// Cloudinit is the base64 encoded cloud-init user data.
+	Cloudinit string `json:"cloudinit,omitempty"`
+	// Tags are encoded with encodeTags.
+	Tags string `json:"tags,omitempty"`
+	// Subnet places the instance in a subnet of the vpc.
+	Subnet string `json:"subnet,omitempty"`
+}
+
+// createCloudInstance is utho.CloudInstancesService.Create with cloud-init user data, tags and subnet support.
+func createCloudInstance(client utho.Client, params createCloudInstanceParams) (*utho.CreateCloudInstanceResponse, error) {
+	reqUrl := "cloud/deploy"
+	req, _ := client.NewRequest("POST", reqUrl, &params)
+
+	var cloudInstance utho.CreateCloudInstanceResponse
+	_, err := client.Do(req, &cloudInstance)
+	if err != nil {
+		return nil, err
+	}
+	if cloudInstance.Status != "success" && cloudInstance.Status != "" {
+		return nil, errors.New(cloudInstance.Message)
+	}
+
+	return &cloudInstance, nil
+}
+
+// Cloud Instance Rebuild
+type rebuildCloudInstanceParams struct {
+	utho.RebuildCloudInstanceParams
+	RootPassword string `json:"root_password,omitempty"`
+	Sshkeys      string `json:"sshkeys,omitempty"`
+}
+
+// rebuildCloudInstance is utho.CloudInstancesService.Rebuild with root password and ssh keys support.
+func rebuildCloudInstance(client utho.Client, instanceId string, params rebuildCloudInstanceParams) (*utho.BasicResponse, error) {
+	reqUrl := "cloud/" + instanceId + "/rebuild"
+	req, _ := client.NewRequest("POST", reqUrl, &params)
+
+	var basicResponse utho.BasicResponse
+	_, err := client.Do(req, &basicResponse)
+	if err != nil {
+		return nil, err
+	}
+	if basicResponse.Status != "success" && basicResponse.Status != "" {
+		return nil, errors.New(basicResponse.Message)
+	}
+
+	return &basicResponse, nil
+}
+
+// Cloud Instance Networks
+// utho-go does not read the ipv6 addresses of an instance, nor manage ipv6, public ips and vpc attachments.
+type cloudInstance struct {
+	utho.CloudInstance
+	Networks cloudInstanceNetworks `json:"networks"`
+	Tags     string                `json:"tags"`
+}
+
+type cloudInstanceNetworks struct {
+	Public  cloudInstancePublicNetworks `json:"public"`
+	Private utho.Private                `json:"private"`
+}
+
+type cloudInstancePublicNetworks struct {
+	V4 utho.V4PublicArray `json:"v4"`
+	V6 []cloudInstanceV6  `json:"v6"`
+}
+
+type cloudInstanceV6 struct {
+	IPAddress string `json:"ip_address,omitempty"`
+	Netmask   string `json:"netmask,omitempty"`
+	Gateway   string `json:"gateway,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Primary   string `json:"primary,omitempty"`
+	Rdns      string `json:"rdns,omitempty"`
+}
+
+type cloudInstanceList struct {
+	CloudInstance []cloudInstance `json:"cloud"`
+	Status        string          `json:"status"`
+	Message       string          `json:"message"`
+}
+
+type cloudInstanceVpcParams struct {
+	Vpc string `json:"vpc"`
+}
+
+type cloudInstanceIPParams struct {
+	IP string `json:"ip"`
+}
+
+// errCloudInstanceNotFound is returned when the instance does not exist.
+var errCloudInstanceNotFound = errors.New("NotFound")
+
+// readCloudInstance is utho.CloudInstancesService.Read with the ipv6 addresses and tags of the instance.
+func readCloudInstance(client utho.Client, instanceId string) (*cloudInstance, error) {
+	reqUrl := "cloud/" + instanceId
+	req, _ := client.NewRequest("GET", reqUrl)
+
+	var cloudInstances cloudInstanceList
+	_, err := client.Do(req, &cloudInstances)
+	if err != nil {
+		return nil, err
+	}
+	if cloudInstances.Status != "success" && cloudInstances.Status != "" {
+		return nil, errors.New(cloudInstances.Message)
+	}
+	if len(cloudInstances.CloudInstance) == 0 {
+		return nil, errCloudInstanceNotFound
+	}
+
+	return &cloudInstances.CloudInstance[0], nil
+}
+
+// readCloudInstances is utho.CloudInstancesService.List with the ipv6 addresses and tags of the instances.
+func readCloudInstances(client utho.Client) ([]cloudInstance, error) {
+	reqUrl := "cloud"
+	req, _ := client.NewRequest("GET", reqUrl)
+
+	var cloudInstances cloudInstanceList
+	_, err := client.Do(req, &cloudInstances)
+	if err != nil {
+		return nil, err
+	}
+	if cloudInstances.Status != "success" && cloudInstances.Status != "" {
+		return nil, errors.New(cloudInstances.Message)
+	}
+
+	return cloudInstances.CloudInstance, nil
+}
+
+// enableCloudInstanceIPv6 assigns an ipv6 address to the instance.
+func enableCloudInstanceIPv6(client utho.Client, instanceId string) (*utho.BasicResponse, error) {
+	return cloudInstanceAction(client, instanceId, "enableipv6", nil)
+}
+
+// disableCloudInstanceIPv6 removes the ipv6 address of the instance.
+func disableCloudInstanceIPv6(client utho.Client, instanceId string) (*utho.BasicResponse, error) {
+	return cloudInstanceAction(client, instanceId, "disableipv6", nil)
+}
+
+// removeCloudInstancePublicIP releases an additional public ip of the instance, the primary ip can not be removed.
+func removeCloudInstancePublicIP(client utho.Client, instanceId, ip string) (*utho.BasicResponse, error) {
+	return cloudInstanceAction(client, instanceId, "removepublicip", &cloudInstanceIPParams{IP: ip})
+}
+
+// attachCloudInstanceVpc adds a private network interface in the vpc to the instance.
+func attachCloudInstanceVpc(client utho.Client, instanceId, vpcId string) (*utho.BasicResponse, error) {
+	return cloudInstanceAction(client, instanceId, "attachvpc", &cloudInstanceVpcParams{Vpc: vpcId})
+}
+
+// detachCloudInstanceVpc removes the private network interface in the vpc from the instance.
+func detachCloudInstanceVpc(client utho.Client, instanceId, vpcId string) (*utho.BasicResponse, error) {
+	return cloudInstanceAction(client, instanceId, "detachvpc", &cloudInstanceVpcParams{Vpc: vpcId})
+}
+
+func cloudInstanceAction(client utho.Client, instanceId, action string, params any) (*utho.BasicResponse, error) {
+	reqUrl := "cloud/" + instanceId + "/" + action
+	req, _ := client.NewRequest("POST", reqUrl, params)
+
+	var basicResponse utho.BasicResponse
+	_, err := client.Do(req, &basicResponse)
+	if err != nil {
+		return nil, err
+	}
+	if basicResponse.Status != "success" && basicResponse.Status != "" {
+		return nil, errors.New(basicResponse.Message)
+	}
+
+	return &basicResponse, nil
+}
+
+// Cloud Instance Tags
+type cloudInstanceTagsParams struct {
+	Tags string `json:"tags"`
+}
+
+// updateCloudInstanceTags replaces the tags of the instance, tags are encoded with encodeTags.
+func updateCloudInstanceTags(client utho.Client, instanceId, tags string) (*utho.BasicResponse, error) {
+	return cloudInstanceAction(client, instanceId, "tags", &cloudInstanceTagsParams{Tags: tags})
+}
+
+// VPC Subnets
+// utho-go creates subnets but does not list or delete them, nor rename a vpc.
+type vpcSubnet struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	VpcID        string `json:"vpcid"`
+	Network      string `json:"network"`
+	Size         string `json:"size"`
+	AssignPublic string `json:"assign_public"`
+}
+
+type vpcSubnetList struct {
+	Subnets []vpcSubnet `json:"subnets"`
+	Status  string      `json:"status,omitempty"`
+	Message string      `json:"message,omitempty"`
+}
+
+type updateVpcParams struct {
+	Name string `json:"name"`
+}
+
+// listVpcSubnets returns the subnets of the vpc.
+func listVpcSubnets(client utho.Client, vpcId string) ([]vpcSubnet, error) {
+	reqUrl := "vpc/" + vpcId + "/subnets"
+	req, _ := client.NewRequest("GET", reqUrl)
+
+	var subnets vpcSubnetList
+	_, err := client.Do(req, &subnets)
+	if err != nil {
+		return nil, err
+	}
+	if subnets.Status != "success" && subnets.Status != "" {
+		return nil, errors.New(subnets.Message)
+	}
+	if len(subnets.Subnets) == 0 {
+		return []vpcSubnet{}, nil
+	}
+	return subnets.Subnets, nil
+}
+
+// deleteVpcSubnet deletes a subnet, it must not have resources attached.
+func deleteVpcSubnet(client utho.Client, vpcId, subnetId string) (*utho.DeleteResponse, error) {
+	reqUrl := "vpc/" + vpcId + "/subnet/" + subnetId + "/destroy"
+	req, _ := client.NewRequest("DELETE", reqUrl)
+
+	var delResponse utho.DeleteResponse
+	_, err := client.Do(req, &delResponse)
+	if err != nil {
+		return nil, err
+	}
+	if delResponse.Status != "success" && delResponse.Status != "" {
+		return nil, errors.New(delResponse.Message)
+	}
+
+	return &delResponse, nil
+}
+
+// updateVpc renames the vpc.
+func updateVpc(client utho.Client, vpcId string, params updateVpcParams) (*utho.BasicResponse, error) {
+	reqUrl := "vpc/" + vpcId + "/update"
+	req, _ := client.NewRequest("POST", reqUrl, &params)
+
+	var basicResponse utho.BasicResponse
+	_, err := client.Do(req, &basicResponse)
+	if err != nil {
+		return nil, err
+	}
+	if basicResponse.Status != "success" && basicResponse.Status != "" {
+		return nil, errors.New(basicResponse.Message)
+	}
+
+	return &basicResponse, nil
+}
+
+// Load Balancer Create
+type createLoadbalancerParams struct {
+	utho.CreateLoadblancerParams
+	Subnet string `json:"subnet,omitempty"`
+}
+
+// createLoadbalancer is utho.LoadbalancersService.Create with subnet support.
+func createLoadbalancer(client utho.Client, params createLoadbalancerParams) (*utho.CreateLoadbalancerResponse, error) {
+	reqUrl := "loadbalancer"
+	req, _ := client.NewRequest("POST", reqUrl, &params)
+
+	var loadbalancer utho.CreateLoadbalancerResponse
+	_, err := client.Do(req, &loadbalancer)
+	if err != nil {
+		return nil, err
+	}
+	if loadbalancer.Status != "success" && loadbalancer.Status != "" {
+		return nil, errors.New(loadbalancer.Message)
+	}
+
+	return &loadbalancer, nil
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// NewVpcDataSource is a helper function to simplify the provider implementation.
+// The vpc is looked up by id or by name, optionally within a zone.
+func NewVpcDataSource() datasource.DataSource {
+	return &resourceDataSource{
+		resource:    NewVpcResource,
+		idAttribute: "id",
+		lookup:      lookupVpc,
+		dcslug:      true,
+	}
+}
+
+func lookupVpc(client utho.Client, name, dcslug string) ([]string, error) {
+	vpcs, err := client.Vpc().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, vpc := range vpcs {
+		if vpc.Name == name && (dcslug == "" || vpc.Dcslug == dcslug) {
+			ids = append(ids, vpc.ID)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccVpcDataSource(t *testing.T) {
+	resourceName := "utho_vpc.example"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "utho_vpc" "example" {
+	dcslug  = "innoida"
+	name    = "example-data-source"
+	planid  = "1008"
+	network = "10.210.100.0"
+	size    = "24"
+}
+
+data "utho_vpc" "by_id" {
+	id = utho_vpc.example.id
+}
+
+data "utho_vpc" "by_name" {
+	name   = utho_vpc.example.name
+	dcslug = "innoida"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.utho_vpc.by_id", "network", resourceName, "network"),
+					resource.TestCheckResourceAttrPair("data.utho_vpc.by_name", "id", resourceName, "id"),
+					resource.TestCheckResourceAttr("data.utho_vpc.by_name", "dcslug", "innoida"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// The range of a vpc is its network and size, or the next free block of the size in its supernet.
+// Vpc ranges in a zone must not overlap, they are checked against the vpcs of the account when planning.
+
+var _ resource.ConfigValidator = vpcNetworkValidator{}
+
+// vpcNetworkValidator checks that the vpc range is a private range of a supported size.
+type vpcNetworkValidator struct{}
+
+func (v vpcNetworkValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("network and supernet must be RFC 1918 private ranges and size must be between %d and %d", vpcMinPrefixSize, vpcMaxPrefixSize)
+}
+
+func (v vpcNetworkValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v vpcNetworkValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var network, size, supernet types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("network"), &network)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("size"), &size)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("supernet"), &supernet)...)
+	if resp.Diagnostics.HasError() || size.IsNull() || size.IsUnknown() {
+		return
+	}
+
+	bits, err := strconv.Atoi(size.ValueString())
+	if err != nil || bits < vpcMinPrefixSize || bits > vpcMaxPrefixSize {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("size"),
+			"Invalid size",
+			fmt.Sprintf("The vpc size %q must be a prefix size between %d and %d", size.ValueString(), vpcMinPrefixSize, vpcMaxPrefixSize),
+		)
+		return
+	}
+
+	if !network.IsNull() && !network.IsUnknown() {
+		prefix, err := networkCIDR(network.ValueString(), size.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("network"), "Invalid network", err.Error())
+		} else if !isPrivateCIDR(prefix) {
+			resp.Diagnostics.AddAttributeError(path.Root("network"), "Invalid network", privateRangeMessage(prefix))
+		}
+	}
+
+	if !supernet.IsNull() && !supernet.IsUnknown() {
+		prefix, err := parseCIDR(supernet.ValueString())
+		if err != nil {
+			// reported by the cidr validator
+			return
+		}
+		if !isPrivateCIDR(prefix) {
+			resp.Diagnostics.AddAttributeError(path.Root("supernet"), "Invalid supernet", privateRangeMessage(prefix))
+		} else if prefix.Bits() > bits {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("supernet"),
+				"Invalid supernet",
+				fmt.Sprintf("A /%d vpc does not fit in the supernet %s", bits, prefix),
+			)
+		}
+	}
+}
+
+func privateRangeMessage(prefix netip.Prefix) string {
+	return fmt.Sprintf("%s is not within the private ranges %v", prefix, privateRanges)
+}
+
+// validateVpcNetworkPlan checks a new vpc range against the vpcs of the account in the same zone,
+// and that the supernet of a vpc without a network has a free block.
+func validateVpcNetworkPlan(ctx context.Context, client utho.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if client == nil || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+	changed := false
+	for _, attribute := range []string{"dcslug", "network", "size", "supernet"} {
+		_, attributeChanged := plannedChange(ctx, req, resp, attribute)
+		changed = changed || attributeChanged
+	}
+	if !changed {
+		return
+	}
+
+	var plan VpcResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Dcslug.IsUnknown() || plan.Size.IsUnknown() {
+		return
+	}
+	attribute := path.Root("network")
+	if plan.Network.IsUnknown() {
+		attribute = path.Root("supernet")
+		if plan.Supernet.IsNull() || plan.Supernet.IsUnknown() {
+			return
+		}
+	}
+
+	vpcs, err := client.Vpc().List()
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(attribute, "Unable to validate "+attribute.String(), "Could not list utho vpcs: "+err.Error())
+		return
+	}
+	if plan.Network.IsUnknown() {
+		if _, err := allocateVpcNetwork(vpcs, plan); err != nil {
+			resp.Diagnostics.AddAttributeError(attribute, "Invalid supernet", err.Error())
+		}
+		return
+	}
+
+	prefix, err := networkCIDR(plan.Network.ValueString(), plan.Size.ValueString())
+	if err != nil {
+		// reported by the config validator
+		return
+	}
+	if err := checkVpcOverlap(prefix, vpcs, plan.Dcslug.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(attribute, "Invalid network", err.Error())
+	}
+}
+
+// allocateVpcNetwork returns the next free block of the planned size in the planned supernet.
+func allocateVpcNetwork(vpcs []utho.Vpc, plan VpcResourceModel) (netip.Prefix, error) {
+	supernet, err := parseCIDR(plan.Supernet.ValueString())
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	bits, err := strconv.Atoi(plan.Size.ValueString())
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%q is not a prefix size", plan.Size.ValueString())
+	}
+	return nextFreeCIDR(supernet, bits, vpcRanges(vpcs, plan.Dcslug.ValueString()))
+}
+
+// checkVpcOverlap checks that the range does not overlap the vpcs in the zone.
+// A replaced vpc is counted as well, vpcs can not be deleted so it keeps its range.
+func checkVpcOverlap(prefix netip.Prefix, vpcs []utho.Vpc, dcslug string) error {
+	for _, vpc := range vpcs {
+		if vpc.Dcslug != dcslug {
+			continue
+		}
+		used, err := networkCIDR(vpc.Network, vpc.Size)
+		if err == nil && used.Overlaps(prefix) {
+			return fmt.Errorf("%s overlaps %s of vpc %s in zone %s", prefix, used, vpc.Name, dcslug)
+		}
+	}
+	return nil
+}
+
+// vpcRanges returns the ranges of the vpcs in the zone.
+func vpcRanges(vpcs []utho.Vpc, dcslug string) []netip.Prefix {
+	ranges := []netip.Prefix{}
+	for _, vpc := range vpcs {
+		if vpc.Dcslug != dcslug {
+			continue
+		}
+		if used, err := networkCIDR(vpc.Network, vpc.Size); err == nil {
+			ranges = append(ranges, used)
+		}
+	}
+	return ranges
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+func TestVpcNetworkValidator(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewVpcResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	for name, tc := range map[string]struct {
+		network, size, supernet any
+		expectError             bool
+	}{
+		"private network":     {network: "10.210.100.0", size: "24"},
+		"supernet":            {supernet: "192.168.0.0/16", size: "24"},
+		"public network":      {network: "103.146.242.0", size: "24", expectError: true},
+		"host address":        {network: "10.210.100.1", size: "24", expectError: true},
+		"typo":                {network: "10.210.100", size: "24", expectError: true},
+		"size too large":      {network: "10.0.0.0", size: "8", expectError: true},
+		"size too small":      {network: "10.210.100.0", size: "30", expectError: true},
+		"size not a number":   {network: "10.210.100.0", size: "/24", expectError: true},
+		"public supernet":     {supernet: "100.64.0.0/10", size: "24", expectError: true},
+		"supernet too small":  {supernet: "10.210.100.0/26", size: "24", expectError: true},
+		"unknown size":        {network: "103.146.242.0", size: tftypes.UnknownValue},
+		"172.16.0.0/12 range": {network: "172.31.255.0", size: "24"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: testObject(schemaResp.Schema, map[string]tftypes.Value{
+				"network":  tftypes.NewValue(tftypes.String, tc.network),
+				"size":     tftypes.NewValue(tftypes.String, tc.size),
+				"supernet": tftypes.NewValue(tftypes.String, tc.supernet),
+			})}
+
+			resp := &fwresource.ValidateConfigResponse{}
+			vpcNetworkValidator{}.ValidateResource(ctx, fwresource.ValidateConfigRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Errorf("expected error %t, got %v", tc.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestVpcNetworkAllocation(t *testing.T) {
+	vpcs := []utho.Vpc{
+		{ID: "1", Name: "web", Dcslug: "innoida", Network: "10.210.0.0", Size: "24"},
+		{ID: "2", Name: "db", Dcslug: "innoida", Network: "10.210.1.0", Size: "25"},
+		{ID: "3", Name: "other-zone", Dcslug: "inmumbaizone2", Network: "10.210.2.0", Size: "24"},
+	}
+
+	if err := checkVpcOverlap(netip.MustParsePrefix("10.210.1.0/24"), vpcs, "innoida"); err == nil {
+		t.Error("checkVpcOverlap() should report the overlap with vpc db")
+	}
+	if err := checkVpcOverlap(netip.MustParsePrefix("10.210.2.0/24"), vpcs, "innoida"); err != nil {
+		t.Errorf("checkVpcOverlap() error = %v, vpcs in other zones do not overlap", err)
+	}
+	if err := checkVpcOverlap(netip.MustParsePrefix("10.210.0.0/24"), vpcs, "innoida"); err == nil {
+		t.Error("checkVpcOverlap() should report the overlap with a replaced vpc, vpcs are not deleted")
+	}
+
+	plan := VpcResourceModel{Dcslug: types.StringValue("innoida"), Size: types.StringValue("24"), Supernet: types.StringValue("10.210.0.0/16")}
+	prefix, err := allocateVpcNetwork(vpcs, plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prefix.String() != "10.210.2.0/24" {
+		t.Errorf("allocateVpcNetwork() = %s, want 10.210.2.0/24", prefix)
+	}
+
+	plan.Supernet = types.StringValue("10.210.0.0/23")
+	if _, err := allocateVpcNetwork(vpcs, plan); !errors.Is(err, errNoFreeCIDR) {
+		t.Errorf("allocateVpcNetwork() error = %v, want %v", err, errNoFreeCIDR)
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/uthoplatforms/utho-go/utho"
@@ -16,10 +18,11 @@ import (
 
 // implement resource interfaces.
 var (
-	_ resource.Resource                = &VpcResource{}
-	_ resource.ResourceWithConfigure   = &VpcResource{}
-	_ resource.ResourceWithImportState = &VpcResource{}
-	_ resource.ResourceWithModifyPlan  = &VpcResource{}
+	_ resource.Resource                     = &VpcResource{}
+	_ resource.ResourceWithConfigure        = &VpcResource{}
+	_ resource.ResourceWithImportState      = &VpcResource{}
+	_ resource.ResourceWithModifyPlan       = &VpcResource{}
+	_ resource.ResourceWithConfigValidators = &VpcResource{}
 )
 
 // NewVpcResource is a helper function to simplify the provider implementation.
@@ -35,14 +38,16 @@ type (
 
 	// VpcResource is the model implementation.
 	VpcResourceModel struct {
-		Id        types.String `tfsdk:"id"`
-		Name      types.String `tfsdk:"name"`
-		Dcslug    types.String `tfsdk:"dcslug"`
-		Planid    types.String `tfsdk:"planid"`
-		Network   types.String `tfsdk:"network"`
-		Size      types.String `tfsdk:"size"`
-		Total     types.Int64  `tfsdk:"total"`
-		Available types.Int64  `tfsdk:"available"`
+		Id                 types.String `tfsdk:"id"`
+		Name               types.String `tfsdk:"name"`
+		Dcslug             types.String `tfsdk:"dcslug"`
+		Planid             types.String `tfsdk:"planid"`
+		Network            types.String `tfsdk:"network"`
+		Size               types.String `tfsdk:"size"`
+		Supernet           types.String `tfsdk:"supernet"`
+		Total              types.Int64  `tfsdk:"total"`
+		Available          types.Int64  `tfsdk:"available"`
+		DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 	}
 )
 
@@ -77,9 +82,8 @@ func (s *VpcResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				Computed: true,
 			},
 			"name": schema.StringAttribute{
-				Required:      true,
-				Description:   "Provide VPC name eg: vpc1",
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Required:    true,
+				Description: "Provide VPC name eg: vpc1",
 			},
 			"dcslug": schema.StringAttribute{
 				Required:      true,
@@ -88,23 +92,32 @@ func (s *VpcResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 			},
 			"planid": schema.StringAttribute{
 				Required:      true,
-				Description:   "Provide network eg: 10.210.100.0",
+				Description:   "Provide the planid eg: 1008",
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
 			"network": schema.StringAttribute{
-				Required:    true,
-				Description: "Provide the planid eg: 1008",
+				Optional:    true,
+				Computed:    true,
+				Description: "Provide network eg: 10.210.100.0, a RFC 1918 private network address. Conflicts with supernet",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"size": schema.StringAttribute{
 				Required:      true,
-				Description:   "Provide subnet size eg: 24",
+				Description:   "Provide subnet size eg: 24, between 16 and 28",
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
-			"total":     schema.Int64Attribute{Computed: true, Description: "total"},
-			"available": schema.Int64Attribute{Computed: true, Description: "k8s available"},
+			"supernet": schema.StringAttribute{
+				Optional:      true,
+				Description:   "Private range eg: 10.210.0.0/16 to allocate the network from, the first block of the size that does not overlap a vpc in the zone is used. Vpcs allocated from the same supernet in one apply should depend on each other. Conflicts with network",
+				Validators:    []validator.String{cidrValidator{}},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"total":               schema.Int64Attribute{Computed: true, Description: "total"},
+			"available":           schema.Int64Attribute{Computed: true, Description: "k8s available"},
+			"deletion_protection": deletionProtectionAttribute("vpc"),
 		},
 	}
 }
@@ -126,6 +139,21 @@ func (s *VpcResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReq
 			state.Planid = types.StringValue("1008")
 		}
 	}
+
+	checkDeletionProtectionPlan(ctx, req, resp, "vpc", "id")
+	validateDcslugPlan(ctx, s.client, req, resp)
+	validateVpcNetworkPlan(ctx, s.client, req, resp)
+}
+
+// ConfigValidators checks the vpc range.
+func (s *VpcResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("network"),
+			path.MatchRoot("supernet"),
+		),
+		vpcNetworkValidator{},
+	}
 }
 
 // Import using vpc as the attribute
@@ -144,6 +172,25 @@ func (s *VpcResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	// allocate the network from the supernet
+	if plan.Network.IsUnknown() {
+		vpcs, err := s.client.Vpc().List()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating vpc",
+				"Could not list utho vpcs to allocate the network: "+err.Error(),
+			)
+			return
+		}
+		prefix, err := allocateVpcNetwork(vpcs, plan)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("supernet"), "Error creating vpc", "Could not allocate the network: "+err.Error())
+			return
+		}
+		network, _ := splitCIDR(prefix)
+		plan.Network = types.StringValue(network)
+	}
+
 	// Generate API request body from plan
 	vpcRequest := utho.CreateVpcParams{
 		Dcslug:  plan.Dcslug.ValueString(),
@@ -174,14 +221,16 @@ func (s *VpcResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	// Map response body to schema and populate Computed attribute values
 	plan = VpcResourceModel{
-		Id:        types.StringValue(vpc.ID),
-		Dcslug:    types.StringValue(plan.Dcslug.ValueString()),
-		Name:      types.StringValue(plan.Name.ValueString()),
-		Planid:    types.StringValue(plan.Planid.ValueString()),
-		Network:   types.StringValue(plan.Network.ValueString()),
-		Size:      types.StringValue(plan.Size.ValueString()),
-		Total:     types.Int64Value(int64(getVpc.Total)),
-		Available: types.Int64Value(int64(getVpc.Available)),
+		Id:                 types.StringValue(vpc.ID),
+		Dcslug:             types.StringValue(plan.Dcslug.ValueString()),
+		Name:               types.StringValue(plan.Name.ValueString()),
+		Planid:             types.StringValue(plan.Planid.ValueString()),
+		Network:            types.StringValue(plan.Network.ValueString()),
+		Size:               types.StringValue(plan.Size.ValueString()),
+		Supernet:           plan.Supernet,
+		Total:              types.Int64Value(int64(getVpc.Total)),
+		Available:          types.Int64Value(int64(getVpc.Available)),
+		DeletionProtection: plan.DeletionProtection,
 	}
 
 	// Set state to fully populated data
@@ -218,14 +267,16 @@ func (s *VpcResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 	// Overwrite items with refreshed state
 	state = VpcResourceModel{
-		Id:        types.StringValue(vpc.ID),
-		Dcslug:    types.StringValue(vpc.Dcslug),
-		Name:      types.StringValue(vpc.Name),
-		Planid:    types.StringValue(state.Planid.ValueString()),
-		Network:   types.StringValue(vpc.Network),
-		Size:      types.StringValue(vpc.Size),
-		Total:     types.Int64Value(int64(vpc.Total)),
-		Available: types.Int64Value(int64(vpc.Available)),
+		Id:                 types.StringValue(vpc.ID),
+		Dcslug:             types.StringValue(vpc.Dcslug),
+		Name:               types.StringValue(vpc.Name),
+		Planid:             types.StringValue(state.Planid.ValueString()),
+		Network:            types.StringValue(vpc.Network),
+		Size:               types.StringValue(vpc.Size),
+		Supernet:           state.Supernet,
+		Total:              types.Int64Value(int64(vpc.Total)),
+		Available:          types.Int64Value(int64(vpc.Available)),
+		DeletionProtection: state.DeletionProtection,
 	}
 
 	// Set refreshed state
@@ -238,11 +289,39 @@ func (s *VpcResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 }
 
 func (s *VpcResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// updating resource is not supported
+	// other changes replace the vpc
+	resp.State.Raw = req.State.Raw.Copy()
+
+	var id, name, currentName types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &currentName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !name.Equal(currentName) {
+		tflog.Debug(ctx, "send rename vpc request")
+		if _, err := updateVpc(s.client, id.ValueString(), updateVpcParams{Name: name.ValueString()}); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating utho vpc",
+				"Could not rename utho vpc "+id.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	}
+
+	adoptPlannedAttributes(ctx, req, resp, "deletion_protection")
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (s *VpcResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, "vpc", id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.AddError(
 		"Error deleteing utho vpc",
 		"Could not delete utho vpc ",
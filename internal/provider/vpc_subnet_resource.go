@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// implement resource interfaces.
+var (
+	_ resource.Resource                = &VpcSubnetResource{}
+	_ resource.ResourceWithConfigure   = &VpcSubnetResource{}
+	_ resource.ResourceWithImportState = &VpcSubnetResource{}
+	_ resource.ResourceWithModifyPlan  = &VpcSubnetResource{}
+)
+
+// NewVpcSubnetResource is a helper function to simplify the provider implementation.
+func NewVpcSubnetResource() resource.Resource {
+	return &VpcSubnetResource{}
+}
+
+// VpcSubnetResource is the resource implementation.
+type VpcSubnetResource struct {
+	client utho.Client
+}
+
+type VpcSubnetResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	VpcID        types.String `tfsdk:"vpc_id"`
+	Name         types.String `tfsdk:"name"`
+	Cidr         types.String `tfsdk:"cidr"`
+	AssignPublic types.Bool   `tfsdk:"assign_public"`
+}
+
+// errSubnetNotFound is returned when the subnet is not in the subnets of the vpc.
+var errSubnetNotFound = errors.New("subnet not found")
+
+// Metadata returns the resource type name.
+func (s *VpcSubnetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vpc_subnet"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *VpcSubnetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(utho.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected VpcSubnet Data Source Configure Type",
+			fmt.Sprintf("Expected utho.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// Schema defines the schema for the resource.
+func (s *VpcSubnetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A subnet of a `utho_vpc`. The cidr must lie within the vpc range and must not overlap the other subnets of the vpc. Cloud instances and load balancers are placed in the subnet with `subnet_id`.",
+		Attributes: map[string]schema.Attribute{
+			"id":     schema.StringAttribute{Computed: true, Description: "Subnet id", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"vpc_id": schema.StringAttribute{Required: true, Description: "Id of the vpc the subnet belongs to", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"name":   schema.StringAttribute{Required: true, Description: "Name of the subnet eg: web", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"cidr": schema.StringAttribute{Required: true, Description: "Ipv4 range of the subnet eg: 10.210.100.0/26",
+				Validators:    []validator.String{cidrValidator{}},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"assign_public": schema.BoolAttribute{Optional: true, Description: "Assign public ips to the resources in the subnet. Defaults to false",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			},
+		},
+	}
+}
+
+// ModifyPlan checks a new cidr against the vpc range and the other subnets of the vpc.
+func (s *VpcSubnetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if s.client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+	_, cidrChanged := plannedChange(ctx, req, resp, "cidr")
+	_, vpcChanged := plannedChange(ctx, req, resp, "vpc_id")
+	if !cidrChanged && !vpcChanged {
+		return
+	}
+
+	var plan VpcSubnetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Cidr.IsUnknown() || plan.VpcID.IsUnknown() {
+		return
+	}
+	vpcId := plan.VpcID.ValueString()
+	prefix, err := parseCIDR(plan.Cidr.ValueString())
+	if err != nil {
+		// reported by the cidr validator
+		return
+	}
+
+	vpc, err := s.client.Vpc().Read(vpcId)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("cidr"), "Unable to validate cidr", "Could not read utho vpc "+vpcId+": "+err.Error())
+		return
+	}
+	subnets, err := listVpcSubnets(s.client, vpcId)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("cidr"), "Unable to validate cidr", "Could not list the subnets of utho vpc "+vpcId+": "+err.Error())
+		return
+	}
+
+	// a replaced subnet is deleted before its replacement is created
+	var id types.String
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	}
+	if err := checkSubnetCIDR(prefix, vpc, subnets, id.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("cidr"), "Invalid cidr", err.Error())
+	}
+}
+
+// Import using vpc_id/subnet_id as the attribute
+func (s *VpcSubnetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "/")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: vpc_id/subnet_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vpc_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+}
+
+// Create a new resource.
+func (s *VpcSubnetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "create vpc subnet")
+	// Retrieve values from plan
+	var plan VpcSubnetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prefix, err := parseCIDR(plan.Cidr.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("cidr"), "Invalid cidr", err.Error())
+		return
+	}
+	network, size := splitCIDR(prefix)
+	assignPublic := "0"
+	if plan.AssignPublic.ValueBool() {
+		assignPublic = "1"
+	}
+
+	// Generate API request body from plan
+	subnetRequest := utho.CreateSubnetParams{
+		Name:         plan.Name.ValueString(),
+		VpcID:        plan.VpcID.ValueString(),
+		AssignPublic: assignPublic,
+		Network:      network,
+		Size:         size,
+	}
+	tflog.Debug(ctx, "send create vpc subnet request")
+	subnet, err := s.client.Vpc().CreateSubnet(subnetRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating vpc subnet",
+			"Could not create vpc subnet, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = types.StringValue(subnet.ID)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish create vpc subnet")
+}
+
+// Read resource information.
+func (s *VpcSubnetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "read vpc subnet")
+
+	// Get current state
+	var state VpcSubnetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "send list vpc subnets request")
+	subnets, err := listVpcSubnets(s.client, state.VpcID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading utho vpc subnet",
+			"Could not read utho vpc subnet "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	subnet, err := findSubnet(subnets, state.ID.ValueString())
+	if err != nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Overwrite items with refreshed state
+	state.Name = types.StringValue(subnet.Name)
+	if prefix, err := networkCIDR(subnet.Network, subnet.Size); err == nil {
+		state.Cidr = types.StringValue(prefix.String())
+	}
+	if !state.AssignPublic.IsNull() {
+		state.AssignPublic = types.BoolValue(subnet.AssignPublic == "1")
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "finish get vpc subnet request")
+}
+
+func (s *VpcSubnetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// all changes replace the subnet
+	resp.State.Raw = req.State.Raw.Copy()
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (s *VpcSubnetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "delete vpc subnet")
+	// Get current state
+	var state VpcSubnetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "send delete vpc subnet request")
+	// delete vpc subnet
+	_, err := deleteVpcSubnet(s.client, state.VpcID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleteing utho vpc subnet",
+			"Could not delete utho vpc subnet "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// checkSubnetCIDR checks that the subnet lies within the vpc range and does not overlap the other subnets of the vpc.
+// The subnet with the id is the one being replaced and is left out.
+func checkSubnetCIDR(prefix netip.Prefix, vpc *utho.Vpc, subnets []vpcSubnet, id string) error {
+	if vpcPrefix, err := networkCIDR(vpc.Network, vpc.Size); err == nil && !cidrContains(vpcPrefix, prefix) {
+		return fmt.Errorf("%s is not within the range %s of vpc %s", prefix, vpcPrefix, vpc.ID)
+	}
+	for _, subnet := range subnets {
+		if subnet.ID == id {
+			continue
+		}
+		sibling, err := networkCIDR(subnet.Network, subnet.Size)
+		if err != nil {
+			continue
+		}
+		if sibling.Overlaps(prefix) {
+			return fmt.Errorf("%s overlaps %s of subnet %s in vpc %s", prefix, sibling, subnet.Name, vpc.ID)
+		}
+	}
+	return nil
+}
+
+// findSubnet returns the subnet with the id.
+func findSubnet(subnets []vpcSubnet, id string) (*vpcSubnet, error) {
+	for i := range subnets {
+		if subnets[i].ID == id {
+			return &subnets[i], nil
+		}
+	}
+	return nil, errSubnetNotFound
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+func TestAccVpcSubnetResource(t *testing.T) {
+	resourceName := "utho_vpc_subnet.web"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "utho_vpc" "example" {
+	dcslug  = "innoida"
+	name    = "example-subnets"
+	planid  = "1008"
+	network = "10.210.100.0"
+	size    = "24"
+}
+
+resource "utho_vpc_subnet" "web" {
+	vpc_id = utho_vpc.example.id
+	name   = "web"
+	cidr   = "10.210.100.0/26"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "web"),
+					resource.TestCheckResourceAttr(resourceName, "cidr", "10.210.100.0/26"),
+					resource.TestCheckResourceAttrPair(resourceName, "vpc_id", "utho_vpc.example", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[resourceName]
+					return rs.Primary.Attributes["vpc_id"] + "/" + rs.Primary.ID, nil
+				},
+			},
+		},
+	})
+}
+
+func TestCheckSubnetCIDR(t *testing.T) {
+	vpc := &utho.Vpc{ID: "vpc-1", Network: "10.210.100.0", Size: "24"}
+	subnets := []vpcSubnet{
+		{ID: "1", Name: "web", Network: "10.210.100.0", Size: "26"},
+		{ID: "2", Name: "db", Network: "10.210.100.128", Size: "27"},
+	}
+
+	for name, tc := range map[string]struct {
+		cidr, id string
+		wantErr  string
+	}{
+		"free range":          {cidr: "10.210.100.64/26"},
+		"after the last":      {cidr: "10.210.100.160/27"},
+		"outside the vpc":     {cidr: "10.210.101.0/26", wantErr: "is not within the range"},
+		"larger than the vpc": {cidr: "10.210.0.0/16", wantErr: "is not within the range"},
+		"overlaps a sibling":  {cidr: "10.210.100.0/25", wantErr: "overlaps 10.210.100.0/26 of subnet web"},
+		"replaces itself":     {cidr: "10.210.100.0/27", id: "1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := checkSubnetCIDR(netip.MustParsePrefix(tc.cidr), vpc, subnets, tc.id)
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("checkSubnetCIDR() error = %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Fatalf("checkSubnetCIDR() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/uthoplatforms/utho-go/utho"
+)
+
+// NewVpcsDataSource is a helper function to simplify the provider implementation.
+func NewVpcsDataSource() datasource.DataSource {
+	return &listDataSource{
+		typeName:    "_vpcs",
+		attribute:   "vpcs",
+		description: "VPCs",
+		itemAttributes: map[string]schema.Attribute{
+			"id":        schema.StringAttribute{Computed: true, Description: "id"},
+			"name":      schema.StringAttribute{Computed: true, Description: "VPC name"},
+			"dcslug":    schema.StringAttribute{Computed: true, Description: "Zone dcslug"},
+			"network":   schema.StringAttribute{Computed: true, Description: "network"},
+			"size":      schema.StringAttribute{Computed: true, Description: "subnet size"},
+			"total":     schema.Int64Attribute{Computed: true, Description: "total"},
+			"available": schema.Int64Attribute{Computed: true, Description: "available"},
+		},
+		list: listVpcs,
+	}
+}
+
+func listVpcs(client utho.Client) ([]map[string]attr.Value, error) {
+	vpcs, err := client.Vpc().List()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]attr.Value, 0, len(vpcs))
+	for _, vpc := range vpcs {
+		items = append(items, map[string]attr.Value{
+			"id":        types.StringValue(vpc.ID),
+			"name":      types.StringValue(vpc.Name),
+			"dcslug":    types.StringValue(vpc.Dcslug),
+			"network":   types.StringValue(vpc.Network),
+			"size":      types.StringValue(vpc.Size),
+			"total":     types.Int64Value(int64(vpc.Total)),
+			"available": types.Int64Value(int64(vpc.Available)),
+		})
+	}
+	return items, nil
+}